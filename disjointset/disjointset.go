@@ -0,0 +1,90 @@
+/*
+Package disjointset implements a disjoint-set data structure
+which maintains a collection of disjoint dynamic sets, each
+identified by a representative that is some member of the set.
+The sets are represented as rooted trees in a forest where each
+member points only to its parent and the root of each tree is the
+representative of its set. Using the union by rank and path
+compression heuristics, a sequence of m MakeSet, Union and FindSet
+operations, n of which are MakeSet, runs in 0(m α(n))-time where
+α(n) is the very slowly growing inverse Ackermann function
+*/
+package disjointset
+
+// Forest is a disjoint-set forest
+type Forest struct {
+	parent map[interface{}]interface{}
+	rank   map[interface{}]int
+	sets   int
+}
+
+// NewForest returns an empty disjoint-set forest
+func NewForest() *Forest {
+	return &Forest{
+		parent: make(map[interface{}]interface{}),
+		rank:   make(map[interface{}]int),
+	}
+}
+
+// MakeSet creates a new set whose only member (and thus representative)
+// is x. It returns false if x is already a member of some set
+func (f *Forest) MakeSet(x interface{}) bool {
+	if _, ok := f.parent[x]; ok {
+		return false
+	}
+	f.parent[x] = x
+	f.rank[x] = 0
+	f.sets++
+	return true
+}
+
+// FindSet returns the representative of the set containing x
+// and false if x is not a member of any set
+// It uses path compression such that each member on the find
+// path points directly to the root
+func (f *Forest) FindSet(x interface{}) (interface{}, bool) {
+	p, ok := f.parent[x]
+	if !ok {
+		return nil, false
+	}
+	if p != x {
+		p, _ = f.FindSet(p)
+		f.parent[x] = p
+	}
+	return p, true
+}
+
+// Union unites the sets that contain x and y into a new set
+// that is the union of the two sets. The root with the smaller
+// rank is made to point to the root with the larger rank.
+// It returns false if x and y are already in the same set or
+// if either is not a member of any set
+func (f *Forest) Union(x, y interface{}) bool {
+	rx, ok1 := f.FindSet(x)
+	ry, ok2 := f.FindSet(y)
+	if !ok1 || !ok2 || rx == ry {
+		return false
+	}
+	if f.rank[rx] > f.rank[ry] {
+		f.parent[ry] = rx
+	} else {
+		f.parent[rx] = ry
+		if f.rank[rx] == f.rank[ry] {
+			f.rank[ry]++
+		}
+	}
+	f.sets--
+	return true
+}
+
+// Connected returns true if x and y are members of the same set
+func (f *Forest) Connected(x, y interface{}) bool {
+	rx, ok1 := f.FindSet(x)
+	ry, ok2 := f.FindSet(y)
+	return ok1 && ok2 && rx == ry
+}
+
+// Sets returns the number of disjoint sets in the forest
+func (f *Forest) Sets() int {
+	return f.sets
+}
@@ -0,0 +1,31 @@
+package disjointset
+
+import "testing"
+
+func TestUnion(t *testing.T) {
+	f := NewForest()
+	for _, x := range []string{"a", "b", "c", "d", "e", "f"} {
+		f.MakeSet(x)
+	}
+	if f.MakeSet("a") {
+		t.Errorf("expected MakeSet of an existing member to fail")
+	}
+	f.Union("a", "b")
+	f.Union("c", "d")
+	f.Union("b", "d")
+	if f.Union("a", "c") {
+		t.Errorf("expected union of members of the same set to fail")
+	}
+	if !f.Connected("a", "d") {
+		t.Errorf("expected a and d to be in the same set")
+	}
+	if f.Connected("a", "e") {
+		t.Errorf("expected a and e to be in different sets")
+	}
+	if sets := f.Sets(); sets != 3 {
+		t.Errorf("expected %d sets, got %d", 3, sets)
+	}
+	if _, ok := f.FindSet("z"); ok {
+		t.Errorf("expected z not to be a member of any set")
+	}
+}
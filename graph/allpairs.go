@@ -0,0 +1,202 @@
+package graph
+
+import (
+	"math"
+
+	"github.com/willpoint/algor/matrix"
+)
+
+// AllPairs holds the result of an all-pairs shortest-paths
+// computation on a graph G = (V, E)
+// Vertices are indexed by the position of their label in Labels,
+// which are sorted, so that D.Get(i, j) is the shortest-path
+// weight from Labels[i] to Labels[j] (+Inf if j is unreachable
+// from i) and Pi.Get(i, j) is the index of the predecessor of
+// Labels[j] on a shortest path from Labels[i] (-1 for NIL)
+type AllPairs struct {
+	Labels []Label
+	D      *matrix.Matrix
+	Pi     *matrix.Matrix
+
+	index map[Label]int
+}
+
+// newAllPairs returns the initial distance and predecessor
+// matrices of G, where D holds 0 on the diagonal and the weight
+// of each edge (u, v) ∈ E, and Pi holds u for such an edge
+func newAllPairs(G *Graph) *AllPairs {
+	n := len(G.V)
+	ap := &AllPairs{
+		Labels: G.labels(),
+		D:      matrix.NewMatrix(n, n),
+		Pi:     matrix.NewMatrix(n, n),
+		index:  make(map[Label]int, n),
+	}
+	for i, l := range ap.Labels {
+		ap.index[l] = i
+	}
+	ap.D.Fill(math.Inf(1))
+	ap.Pi.Fill(-1)
+	for i := 0; i < n; i++ {
+		ap.D.Set(i, i, 0)
+	}
+	return ap
+}
+
+// Index returns the row (and column) of the vertex labeled l
+func (ap *AllPairs) Index(l Label) (int, bool) {
+	i, ok := ap.index[l]
+	return i, ok
+}
+
+// Distance returns the shortest-path weight from u to v
+// and false if v is not reachable from u
+func (ap *AllPairs) Distance(u, v Label) (int, bool) {
+	i, ok1 := ap.index[u]
+	j, ok2 := ap.index[v]
+	if !ok1 || !ok2 {
+		return 0, false
+	}
+	d := ap.D.Get(i, j)
+	if math.IsInf(d, 1) {
+		return 0, false
+	}
+	return int(d), true
+}
+
+// Predecessor returns the label of the vertex preceding v on
+// a shortest path from u, and false if there is none
+func (ap *AllPairs) Predecessor(u, v Label) (Label, bool) {
+	i, ok1 := ap.index[u]
+	j, ok2 := ap.index[v]
+	if !ok1 || !ok2 {
+		return "", false
+	}
+	p := int(ap.Pi.Get(i, j))
+	if p < 0 {
+		return "", false
+	}
+	return ap.Labels[p], true
+}
+
+// FloydWarshall solves the all-pairs shortest-paths problem on a
+// directed graph G in which negative-weight edges may be present.
+// It considers each vertex k in turn as a possible intermediate
+// vertex of the shortest path from i to j, such that
+// d(i, j) = min(d(i, j), d(i, k) + d(k, j))
+// If some d(i, i) ends up negative, G contains a negative-weight
+// cycle through i which is reported as a *NegativeCycleError
+// This is achieved in an order 0(V³)-time and 0(V²)-space
+func FloydWarshall(G *Graph) (*AllPairs, error) {
+	ap := newAllPairs(G)
+	D, Pi := ap.D.Arrays(), ap.Pi.Arrays()
+	for _, e := range G.arcs() {
+		w := G.weight(e)
+		i, j := ap.index[e.U.Label], ap.index[e.V.Label]
+		if float64(w) < D[i][j] {
+			D[i][j] = float64(w)
+			Pi[i][j] = float64(i)
+		}
+	}
+	n := len(ap.Labels)
+	for k := 0; k < n; k++ {
+		for i := 0; i < n; i++ {
+			if math.IsInf(D[i][k], 1) {
+				continue
+			}
+			for j := 0; j < n; j++ {
+				if d := D[i][k] + D[k][j]; d < D[i][j] {
+					D[i][j] = d
+					Pi[i][j] = Pi[k][j]
+				}
+			}
+		}
+	}
+	for i := 0; i < n; i++ {
+		if D[i][i] < 0 {
+			_, err := BellmanFord(G, ap.Labels[i])
+			return nil, err
+		}
+	}
+	return ap, nil
+}
+
+// Johnson solves the all-pairs shortest-paths problem for sparse
+// graphs that may have negative-weight edges but no negative-weight
+// cycle. It computes a height h(v) for each vertex with the
+// Bellman-Ford algorithm from a new source joined to every vertex
+// with zero-weight edges, and reweights each edge (u, v) to
+// w'(u, v) = w(u, v) + h(u) - h(v) which is nonnegative
+// Dijkstra's algorithm is then run from every vertex on the reweighted
+// graph and each distance restored as d(u, v) = d'(u, v) - h(u) + h(v)
+// This is achieved in the time of |V| runs of Dijkstra
+func Johnson(G *Graph) (*AllPairs, error) {
+	// starting every estimate at 0 is the same as relaxing the
+	// zero-weight edges leaving the new source, which is one more
+	// vertex to account for in the number of passes
+	p := &Paths{
+		Distance:    make(map[Label]int, len(G.V)),
+		Predecessor: make(map[Label]Label),
+	}
+	for l := range G.V {
+		p.Distance[l] = 0
+	}
+	if err := p.relaxEdges(G, G.VNum+1); err != nil {
+		return nil, err
+	}
+	h := p.Distance
+	Gh := reweight(G, h)
+	ap := newAllPairs(G)
+	D, Pi := ap.D.Arrays(), ap.Pi.Arrays()
+	for _, su := range ap.Labels {
+		i := ap.index[su]
+		ph, _ := Dijkstra(Gh, su)
+		for lv, d := range ph.Distance {
+			j := ap.index[lv]
+			D[i][j] = float64(d - h[su] + h[lv])
+			if pv, ok := ph.Predecessor[lv]; ok {
+				Pi[i][j] = float64(ap.index[pv])
+			}
+		}
+	}
+	return ap, nil
+}
+
+// reweight returns a directed copy of G in which the weight of each
+// edge (u, v) is w(u, v) + h(u) - h(v), an edge of an undirected graph
+// giving an edge in each direction
+func reweight(G *Graph, h map[Label]int) *Graph {
+	Gh := NewGraph()
+	for l := range G.V {
+		Gh.AddVertex(l)
+	}
+	for _, u := range G.V {
+		for _, lv := range u.Adj {
+			w := G.weight(NewEdge(u, G.V[lv]))
+			Gh.AddEdge(u.Label, lv, w+h[u.Label]-h[lv])
+		}
+	}
+	return Gh
+}
+
+// PathTo returns a shortest path from u to v by walking the
+// predecessors of v back to u, and ErrNoPath if there is none
+func (ap *AllPairs) PathTo(u, v Label) (*Path, error) {
+	d, ok := ap.Distance(u, v)
+	if !ok {
+		return nil, ErrNoPath
+	}
+	labels := []Label{v}
+	for w := v; w != u; {
+		p, ok := ap.Predecessor(u, w)
+		if !ok || len(labels) > len(ap.Labels) {
+			return nil, ErrNoPath
+		}
+		labels = append(labels, p)
+		w = p
+	}
+	for i, j := 0, len(labels)-1; i < j; i, j = i+1, j-1 {
+		labels[i], labels[j] = labels[j], labels[i]
+	}
+	return &Path{Labels: labels, Weight: d, Hops: len(labels) - 1}, nil
+}
@@ -0,0 +1,85 @@
+package graph
+
+import "testing"
+
+// clrsAllPairs is the graph of figure 25.1 in CLRS
+var clrsAllPairs = []weightedPair{
+	{Pair: [2]string{"1", "2"}, Weight: 3},
+	{Pair: [2]string{"1", "3"}, Weight: 8},
+	{Pair: [2]string{"1", "5"}, Weight: -4},
+	{Pair: [2]string{"2", "4"}, Weight: 1},
+	{Pair: [2]string{"2", "5"}, Weight: 7},
+	{Pair: [2]string{"3", "2"}, Weight: 4},
+	{Pair: [2]string{"4", "1"}, Weight: 2},
+	{Pair: [2]string{"4", "3"}, Weight: -5},
+	{Pair: [2]string{"5", "4"}, Weight: 6},
+}
+
+// clrsAllPairsD is the expected distance matrix for clrsAllPairs
+var clrsAllPairsD = [5][5]int{
+	{0, 1, -3, 2, -4},
+	{3, 0, -4, 1, -1},
+	{7, 4, 0, 5, 3},
+	{2, -1, -5, 0, -2},
+	{8, 5, 1, 6, 0},
+}
+
+func checkAllPairs(t *testing.T, ap *AllPairs) {
+	for i, u := range ap.Labels {
+		for j, v := range ap.Labels {
+			d, ok := ap.Distance(u, v)
+			if !ok || d != clrsAllPairsD[i][j] {
+				t.Errorf("expected d(%s, %s) to be %d, got %d", u, v, clrsAllPairsD[i][j], d)
+			}
+		}
+	}
+	// walking the predecessors from 1 to 2 gives 1 -> 5 -> 4 -> 3 -> 2
+	expected := []Label{"2", "3", "4", "5", "1"}
+	for i, v := 0, Label("2"); v != "1"; i++ {
+		p, ok := ap.Predecessor("1", v)
+		if !ok || p != expected[i+1] {
+			t.Fatalf("expected predecessor of %s to be %s, got %s", v, expected[i+1], p)
+		}
+		v = p
+	}
+}
+
+func TestFloydWarshall(t *testing.T) {
+	G := BuildWeightedGraph(clrsAllPairs)
+	ap, err := FloydWarshall(G)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checkAllPairs(t, ap)
+	t.Log("floyd-warshall:\n", ap.D.Arrays())
+}
+
+func TestJohnson(t *testing.T) {
+	G := BuildWeightedGraph(clrsAllPairs)
+	ap, err := Johnson(G)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checkAllPairs(t, ap)
+	for e, w := range G.E {
+		if e.U.Label == "4" && e.V.Label == "3" && w != -5 {
+			t.Errorf("expected G to keep its weights, got %d for %s", w, e)
+		}
+	}
+}
+
+func TestAllPairsNegativeCycle(t *testing.T) {
+	pairs := []weightedPair{
+		{Pair: [2]string{"a", "b"}, Weight: 2},
+		{Pair: [2]string{"b", "c"}, Weight: -1},
+		{Pair: [2]string{"c", "a"}, Weight: -2},
+		{Pair: [2]string{"d", "a"}, Weight: 1},
+	}
+	if _, err := FloydWarshall(BuildWeightedGraph(pairs)); err == nil {
+		t.Error("expected floyd-warshall to report a negative-weight cycle")
+	}
+	_, err := Johnson(BuildWeightedGraph(pairs))
+	if nc, ok := err.(*NegativeCycleError); !ok || len(nc.Cycle) != 3 {
+		t.Errorf("expected johnson to report a negative-weight cycle, got %v", err)
+	}
+}
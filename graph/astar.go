@@ -0,0 +1,156 @@
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/willpoint/algor/heap"
+)
+
+var (
+	// ErrInadmissible occurs when a heuristic overestimates the
+	// weight of a shortest path from some vertex to the target
+	ErrInadmissible = errors.New("heuristic is not admissible")
+	// ErrInconsistent occurs when a heuristic h does not satisfy
+	// h(u) <= w(u, v) + h(v) for some edge (u, v)
+	ErrInconsistent = errors.New("heuristic is not consistent")
+)
+
+// AStar finds a shortest path from src to dst in a graph G with
+// nonnegative edge weights, guided by a heuristic h(v) estimating
+// the weight of a shortest path from v to dst. Like Dijkstra it keeps
+// the vertices found but not yet expanded in a min-priority queue, keyed
+// on f(v) = g(v) + h(v) where g(v) is the weight of the best path from
+// src to v found so far, so that the search heads towards dst and
+// stops as soon as dst is expanded.
+// The path found is a shortest path if h is admissible, never
+// overestimating, and no vertex is expanded more than once if h is
+// also consistent (see Admissible and Consistent); h(v) = 0 for all v
+// reduces AStar to Dijkstra's algorithm
+// It returns ErrNoPath if dst is not reachable from src
+func AStar(G *Graph, src, dst Label, h func(Label) int) (*Path, error) {
+	if _, ok := G.V[src]; !ok {
+		return nil, ErrVertexNotFound
+	}
+	if _, ok := G.V[dst]; !ok {
+		return nil, ErrVertexNotFound
+	}
+	p := initSingleSource(src)
+	Q := heap.NewIndexedMinPQ()
+	Q.Insert(src, h(src))
+	for !Q.Empty() {
+		min, _, _ := Q.ExtractMin()
+		u := G.V[min.(Label)]
+		if u.Label == dst {
+			return PathTo(p, dst)
+		}
+		for _, j := range u.Adj {
+			if p.relax(G, NewEdge(u, G.V[j])) {
+				// a vertex already expanded is reopened, which
+				// only happens when h is not consistent
+				enqueue(Q, j, p.Distance[j]+h(j))
+			}
+		}
+	}
+	return nil, ErrNoPath
+}
+
+// Admissible checks that h never overestimates the weight of a
+// shortest path to dst, such that h(v) <= d(v, dst) for every vertex
+// v from which dst is reachable, with the weights d(v, dst) computed
+// by Dijkstra's algorithm from dst on the transpose of G
+// It returns ErrInadmissible otherwise
+func Admissible(G *Graph, dst Label, h func(Label) int) error {
+	p, err := Dijkstra(Transpose(G), dst)
+	if err != nil {
+		return err
+	}
+	for v, d := range p.Distance {
+		if h(v) > d {
+			return fmt.Errorf("%v: h(%s) = %d > %d", ErrInadmissible, v, h(v), d)
+		}
+	}
+	return nil
+}
+
+// Consistent checks that h satisfies the triangle inequality
+// h(u) <= w(u, v) + h(v) for every edge (u, v) of G, in both directions
+// for an undirected graph. A consistent heuristic with h(dst) = 0 is
+// also admissible. It returns ErrInconsistent otherwise
+func Consistent(G *Graph, h func(Label) int) error {
+	for _, e := range G.arcs() {
+		u, v := e.U.Label, e.V.Label
+		if w := G.weight(e); h(u) > w+h(v) {
+			return fmt.Errorf("%v: h(%s) = %d > w(%s, %s) + h(%s) = %d", ErrInconsistent, u, h(u), u, v, v, w+h(v))
+		}
+	}
+	return nil
+}
+
+// GridLabel returns the label "r,c" of the cell
+// in row r and column c of a grid graph
+func GridLabel(r, c int) Label {
+	return Label(fmt.Sprintf("%d,%d", r, c))
+}
+
+// gridCell returns the row and column of a grid label
+func gridCell(l Label) (r, c int) {
+	fmt.Sscanf(string(l), "%d,%d", &r, &c)
+	return r, c
+}
+
+// BuildGrid returns an undirected graph of the rows x cols cells of a
+// grid, labeled by GridLabel, where each cell is joined to the cells
+// above, below, left and right of it by edges of unit weight. Cells
+// for which blocked returns true are left out, blocked may be nil
+func BuildGrid(rows, cols int, blocked func(r, c int) bool) *Graph {
+	G := NewUndirectedGraph()
+	open := func(r, c int) bool {
+		return r >= 0 && r < rows && c >= 0 && c < cols && (blocked == nil || !blocked(r, c))
+	}
+	for r := 0; r < rows; r++ {
+		for c := 0; c < cols; c++ {
+			if !open(r, c) {
+				continue
+			}
+			G.AddVertex(GridLabel(r, c))
+			if open(r-1, c) {
+				G.AddEdge(GridLabel(r-1, c), GridLabel(r, c), 1)
+			}
+			if open(r, c-1) {
+				G.AddEdge(GridLabel(r, c-1), GridLabel(r, c), 1)
+			}
+		}
+	}
+	return G
+}
+
+// Manhattan returns the heuristic |r - r'| + |c - c'| for the cell
+// (r', c') of a grid graph labeled dst, which is consistent for
+// BuildGrid as no move covers more than one row or column
+func Manhattan(dst Label) func(Label) int {
+	dr, dc := gridCell(dst)
+	return func(l Label) int {
+		r, c := gridCell(l)
+		return abs(r-dr) + abs(c-dc)
+	}
+}
+
+// Euclidean returns the straight line distance to the cell of a grid
+// graph labeled dst, rounded down to keep it admissible and consistent
+// It suits grids whose weights are at least the distance they cover
+func Euclidean(dst Label) func(Label) int {
+	dr, dc := gridCell(dst)
+	return func(l Label) int {
+		r, c := gridCell(l)
+		return int(math.Sqrt(float64((r-dr)*(r-dr) + (c-dc)*(c-dc))))
+	}
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
@@ -0,0 +1,92 @@
+package graph
+
+import "testing"
+
+// maze is a grid with walls (#) between S and T
+var maze = []string{
+	"S....#....",
+	".###.#.##.",
+	".#...#..#.",
+	".#.###.##.",
+	".#........",
+	".######.#T",
+}
+
+func mazeGrid() *Graph {
+	return BuildGrid(len(maze), len(maze[0]), func(r, c int) bool {
+		return maze[r][c] == '#'
+	})
+}
+
+func TestBuildGrid(t *testing.T) {
+	G := BuildGrid(3, 4, nil)
+	if G.VNum != 12 || G.ENum != 17 {
+		t.Errorf("expected 12 vertices and 17 edges, got %d and %d", G.VNum, G.ENum)
+	}
+	consistent(t, G)
+}
+
+func TestAStar(t *testing.T) {
+	G := mazeGrid()
+	src, dst := GridLabel(0, 0), GridLabel(5, 9)
+	p, _ := Dijkstra(G, src)
+	expected := p.Distance[dst]
+	for name, h := range map[string]func(Label) int{
+		"zero":      func(Label) int { return 0 },
+		"manhattan": Manhattan(dst),
+		"euclidean": Euclidean(dst),
+	} {
+		if err := Consistent(G, h); err != nil {
+			t.Errorf("%s: unexpected error: %v", name, err)
+		}
+		if err := Admissible(G, dst, h); err != nil {
+			t.Errorf("%s: unexpected error: %v", name, err)
+		}
+		path, err := AStar(G, src, dst, h)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+		if path.Weight != expected || path.Hops != expected {
+			t.Errorf("%s: expected a path of weight %d, got %d: %s", name, expected, path.Weight, path)
+		}
+		for i := 1; i < len(path.Labels); i++ {
+			if _, ok := G.Weight(path.Labels[i-1], path.Labels[i]); !ok {
+				t.Errorf("%s: expected (%s, %s) to be an edge", name, path.Labels[i-1], path.Labels[i])
+			}
+		}
+	}
+	if _, err := AStar(G, src, GridLabel(0, 5), Manhattan(GridLabel(0, 5))); err != ErrVertexNotFound {
+		t.Errorf("expected %v, got %v", ErrVertexNotFound, err)
+	}
+	G.AddVertex("island")
+	if _, err := AStar(G, src, "island", func(Label) int { return 0 }); err != ErrNoPath {
+		t.Errorf("expected %v, got %v", ErrNoPath, err)
+	}
+}
+
+func TestHeuristicChecks(t *testing.T) {
+	G := BuildWeightedGraph([]weightedPair{
+		{Pair: [2]string{"s", "a"}, Weight: 1},
+		{Pair: [2]string{"s", "b"}, Weight: 3},
+		{Pair: [2]string{"a", "b"}, Weight: 1},
+		{Pair: [2]string{"b", "t"}, Weight: 3},
+	})
+	// admissible but not consistent, b is expanded before
+	// it is known that it is cheaper to reach through a
+	h := map[Label]int{"s": 0, "a": 4, "b": 0, "t": 0}
+	hf := func(l Label) int { return h[l] }
+	if err := Admissible(G, "t", hf); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := Consistent(G, hf); err == nil {
+		t.Errorf("expected %v", ErrInconsistent)
+	}
+	path, err := AStar(G, "s", "t", hf)
+	if err != nil || path.Weight != 5 {
+		t.Errorf("expected a path of weight 5, got %v: %v", path, err)
+	}
+	h["a"] = 5
+	if err := Admissible(G, "t", hf); err == nil {
+		t.Errorf("expected %v", ErrInadmissible)
+	}
+}
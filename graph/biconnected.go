@@ -0,0 +1,141 @@
+package graph
+
+import "sort"
+
+// biconnectivity holds the articulation points, bridges and
+// biconnected components of a graph found by a single search
+type biconnectivity struct {
+	points     []Label
+	bridges    []Edge
+	components [][]Edge
+}
+
+// ArticulationPoints returns the sorted labels of the articulation
+// points of G, the vertices whose removal disconnects the connected
+// component they are in. The edges of a directed graph are taken
+// regardless of their direction
+// This is achieved in an order 0(V + E)-time
+func ArticulationPoints(G *Graph) []Label {
+	return biconnect(G).points
+}
+
+// Bridges returns the bridges of G, the edges whose removal
+// disconnects the connected component they are in, sorted by
+// label. The edges of a directed graph are taken regardless of
+// their direction, so a pair of antiparallel edges joins its ends
+// as a cycle would and neither of them is a bridge
+// This is achieved in an order 0(V + E)-time
+func Bridges(G *Graph) []Edge {
+	return biconnect(G).bridges
+}
+
+// BiconnectedComponents partitions the edges of G into its biconnected
+// components, the maximal sets of edges any two of which lie on a
+// common simple cycle, a bridge being a component on its own
+// The edges of each component are sorted by label, and the components
+// are ordered by their first edge. The edges of a directed graph are
+// taken regardless of their direction, both edges of an antiparallel
+// pair falling in the same component, and self-loops are left out
+// This is achieved in an order 0(V + E)-time
+func BiconnectedComponents(G *Graph) [][]Edge {
+	return biconnect(G).components
+}
+
+// biconnect runs Tarjan's depth-first search keeping the low-link of
+// each vertex u, the earliest discovery time of a vertex reachable
+// from the subtree rooted at u by following at most one back edge
+// A child v of u with low(v) >= d(u) cannot reach above u without
+// going through it, so u is an articulation point unless it is a root
+// with a single child, and the edges pushed on a stack since the tree
+// edge (u, v) form a biconnected component; (u, v) is a bridge when
+// low(v) > d(u), as nothing in the subtree of v reaches back to u
+func biconnect(G *Graph) *biconnectivity {
+	nbrs := G.neighbors()
+	b := &biconnectivity{}
+	isPoint := make(map[Label]bool)
+	disc := make(map[Label]int, len(G.V))
+	low := make(map[Label]int, len(G.V))
+	var time int
+	var stack []Edge
+	var visit func(u, parent Label, root bool)
+	visit = func(u, parent Label, root bool) {
+		time++
+		disc[u], low[u] = time, time
+		var children int
+		for _, v := range nbrs[u] {
+			switch {
+			case !root && v == parent:
+				// the other edge of an antiparallel pair leads back
+				// to the parent, its edges are already on the stack
+				if len(G.edgesBetween(u, v)) > 1 && disc[v] < low[u] {
+					low[u] = disc[v]
+				}
+			case disc[v] == 0:
+				children++
+				n := len(stack)
+				stack = append(stack, G.edgesBetween(u, v)...)
+				visit(v, u, false)
+				if low[v] < low[u] {
+					low[u] = low[v]
+				}
+				if low[v] >= disc[u] {
+					if !root || children > 1 {
+						isPoint[u] = true
+					}
+					c := append([]Edge(nil), stack[n:]...)
+					stack = stack[:n]
+					sortEdges(c)
+					b.components = append(b.components, c)
+				}
+				if low[v] > disc[u] {
+					b.bridges = append(b.bridges, G.edgeBetween(u, v))
+				}
+			case disc[v] < disc[u]:
+				stack = append(stack, G.edgesBetween(u, v)...)
+				if disc[v] < low[u] {
+					low[u] = disc[v]
+				}
+			}
+		}
+	}
+	for _, l := range G.labels() {
+		if disc[l] == 0 {
+			visit(l, "", true)
+		}
+	}
+	for l := range isPoint {
+		b.points = append(b.points, l)
+	}
+	sortLabels(b.points)
+	sortEdges(b.bridges)
+	sort.Slice(b.components, func(i, j int) bool {
+		x, y := b.components[i][0], b.components[j][0]
+		if x.U.Label != y.U.Label {
+			return x.U.Label < y.U.Label
+		}
+		return x.V.Label < y.V.Label
+	})
+	return b
+}
+
+// edgeBetween returns the edge of G joining u and v
+// whichever way it is held in E
+func (G *Graph) edgeBetween(u, v Label) Edge {
+	e := G.key(NewEdge(G.V[u], G.V[v]))
+	if _, ok := G.E[e]; !ok {
+		return NewEdge(G.V[v], G.V[u])
+	}
+	return e
+}
+
+// edgesBetween returns the edges of G joining u and v, which are
+// both edges of an antiparallel pair in a directed graph
+func (G *Graph) edgesBetween(u, v Label) []Edge {
+	var edges []Edge
+	for _, e := range []Edge{NewEdge(G.V[u], G.V[v]), NewEdge(G.V[v], G.V[u])} {
+		if _, ok := G.E[e]; ok {
+			edges = append(edges, e)
+		}
+	}
+	return edges
+}
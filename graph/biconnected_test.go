@@ -0,0 +1,75 @@
+package graph
+
+import (
+	"reflect"
+	"testing"
+)
+
+// twoTriangles are two triangles joined by a bridge, with another
+// bridge hanging off one of them and a separate edge on its own
+var twoTriangles = [][2]string{
+	{"a", "b"}, {"b", "c"}, {"c", "a"},
+	{"c", "d"},
+	{"d", "e"}, {"e", "f"}, {"f", "d"},
+	{"f", "g"},
+	{"i", "j"},
+	{"h", ""},
+}
+
+func edgeStrings(edges []Edge) []string {
+	s := make([]string, len(edges))
+	for i, e := range edges {
+		s[i] = string(e.U.Label) + string(e.V.Label)
+	}
+	return s
+}
+
+func TestBiconnectivity(t *testing.T) {
+	for name, G := range map[string]*Graph{
+		"undirected": BuildUndirectedGraph(twoTriangles),
+		"directed":   BuildGraph(append(twoTriangles, [2]string{"b", "a"})),
+	} {
+		if points := ArticulationPoints(G); !reflect.DeepEqual(points, []Label{"c", "d", "f"}) {
+			t.Errorf("%s: expected articulation points [c d f], got %v", name, points)
+		}
+		if bridges := edgeStrings(Bridges(G)); !reflect.DeepEqual(bridges, []string{"cd", "fg", "ij"}) {
+			t.Errorf("%s: expected bridges [cd fg ij], got %v", name, bridges)
+		}
+		var components [][]string
+		for _, c := range BiconnectedComponents(G) {
+			components = append(components, edgeStrings(c))
+		}
+		expected := [][]string{{"ab", "ba", "bc", "ca"}, {"cd"}, {"de", "ef", "fd"}, {"fg"}, {"ij"}}
+		if name == "undirected" {
+			expected[0] = []string{"ab", "ac", "bc"}
+			expected[2] = []string{"de", "df", "ef"}
+		}
+		if !reflect.DeepEqual(components, expected) {
+			t.Errorf("%s: expected components %v, got %v", name, expected, components)
+		}
+	}
+}
+
+func TestBiconnectedCycle(t *testing.T) {
+	G := BuildUndirectedGraph([][2]string{{"a", "b"}, {"b", "c"}, {"c", "d"}, {"d", "a"}, {"a", "c"}})
+	if points := ArticulationPoints(G); len(points) != 0 {
+		t.Errorf("expected no articulation points, got %v", points)
+	}
+	if bridges := Bridges(G); len(bridges) != 0 {
+		t.Errorf("expected no bridges, got %v", bridges)
+	}
+	if c := BiconnectedComponents(G); len(c) != 1 || len(c[0]) != 5 {
+		t.Errorf("expected a single component of 5 edges, got %v", c)
+	}
+	G = BuildGraph([][2]string{{"a", "b"}, {"b", "a"}, {"b", "c"}})
+	if bridges := edgeStrings(Bridges(G)); !reflect.DeepEqual(bridges, []string{"bc"}) {
+		t.Errorf("expected bridges [bc], got %v", bridges)
+	}
+	var components [][]string
+	for _, c := range BiconnectedComponents(G) {
+		components = append(components, edgeStrings(c))
+	}
+	if expected := [][]string{{"ab", "ba"}, {"bc"}}; !reflect.DeepEqual(components, expected) {
+		t.Errorf("expected components %v, got %v", expected, components)
+	}
+}
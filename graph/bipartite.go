@@ -0,0 +1,313 @@
+package graph
+
+import (
+	"errors"
+	"math"
+
+	"github.com/willpoint/algor/matrix"
+)
+
+var (
+	// ErrNoAssignment occurs when there are fewer jobs than workers,
+	// or the workers cannot all be given distinct jobs they can do
+	ErrNoAssignment = errors.New("no assignment of every worker")
+)
+
+// OddCycleError is returned when a graph is required to be bipartite
+// but is not, Cycle holds the labels of the vertices on one of its
+// cycles of odd length, which no two-coloring can alternate around
+type OddCycleError struct {
+	Cycle []Label
+}
+
+// Error implements the error interface
+func (e *OddCycleError) Error() string {
+	return "odd " + formatCycle(e.Cycle)
+}
+
+// Bipartite checks whether the vertices of G can be split into two
+// sides such that every edge, regardless of its direction, joins
+// vertices on different sides, by coloring the vertices of each
+// connected component alternately 0 and 1 in breadth-first order from
+// its vertex with the smallest label. It returns the side of each
+// vertex, or an *OddCycleError if an edge joins two vertices of the
+// same color, closing a cycle of odd length through their common
+// ancestor in the breadth-first tree
+// This is achieved in an order 0(V + E)-time
+func Bipartite(G *Graph) (map[Label]int, error) {
+	for e := range G.E {
+		if e.U == e.V {
+			return nil, &OddCycleError{Cycle: []Label{e.U.Label}}
+		}
+	}
+	nbrs := G.neighbors()
+	side := make(map[Label]int, len(G.V))
+	for _, s := range G.labels() {
+		if _, ok := side[s]; ok {
+			continue
+		}
+		p := newPaths(s)
+		side[s] = 0
+		Q := []Label{s}
+		for len(Q) > 0 {
+			u := Q[0]
+			Q = Q[1:]
+			for _, v := range nbrs[u] {
+				if !p.Reached(v) {
+					p.Distance[v] = p.Distance[u] + 1
+					p.Predecessor[v] = u
+					side[v] = 1 - side[u]
+					Q = append(Q, v)
+				} else if side[v] == side[u] {
+					return nil, &OddCycleError{Cycle: p.oddCycle(u, v)}
+				}
+			}
+		}
+	}
+	return side, nil
+}
+
+// oddCycle returns the cycle closed by the edge (u, v) between two
+// vertices at the same depth of the breadth-first tree of p, going
+// down from their lowest common ancestor to u, across to v and back up
+func (p *Paths) oddCycle(u, v Label) []Label {
+	var down, up []Label
+	for u != v {
+		down = append(down, u)
+		up = append(up, v)
+		u, v = p.Predecessor[u], p.Predecessor[v]
+	}
+	cycle := []Label{u}
+	for i := len(down) - 1; i >= 0; i-- {
+		cycle = append(cycle, down[i])
+	}
+	return append(cycle, up...)
+}
+
+// HopcroftKarp returns a maximum matching of a bipartite graph G, a
+// largest set of edges no two of which share a vertex, mapping each
+// matched vertex to its mate on the other side, so that it holds
+// every edge of the matching both ways
+// Each phase finds, by a breadth-first search from the unmatched
+// vertices of side 0, the length of the shortest augmenting paths,
+// those alternating between unmatched and matched edges from an
+// unmatched vertex to another, and then by depth-first search a
+// maximal set of vertex-disjoint such paths along which the matching
+// is flipped, growing it by one edge per path
+// It returns an *OddCycleError if G is not bipartite
+// This is achieved in an order 0(E√V)-time
+func HopcroftKarp(G *Graph) (map[Label]Label, error) {
+	side, err := Bipartite(G)
+	if err != nil {
+		return nil, err
+	}
+	nbrs := G.neighbors()
+	var left []Label
+	for _, l := range G.labels() {
+		if side[l] == 0 {
+			left = append(left, l)
+		}
+	}
+	mate := make(map[Label]Label, len(G.V))
+	dist := make(map[Label]int, len(left))
+	// free is the length, in edges of the matching, of the shortest
+	// alternating paths that reach an unmatched vertex of side 1,
+	// beyond which a phase neither searches nor augments
+	free := infinity
+	// levels labels the vertices of side 0 by their distance
+	// along alternating paths from an unmatched one, up to free,
+	// and reports whether an augmenting path was found
+	levels := func() bool {
+		var Q []Label
+		for _, u := range left {
+			dist[u] = infinity
+			if _, ok := mate[u]; !ok {
+				dist[u] = 0
+				Q = append(Q, u)
+			}
+		}
+		free = infinity
+		for len(Q) > 0 {
+			u := Q[0]
+			Q = Q[1:]
+			if dist[u] >= free {
+				continue
+			}
+			for _, v := range nbrs[u] {
+				w, ok := mate[v]
+				if !ok {
+					if free == infinity {
+						free = dist[u] + 1
+					}
+				} else if dist[w] == infinity {
+					dist[w] = dist[u] + 1
+					Q = append(Q, w)
+				}
+			}
+		}
+		return free != infinity
+	}
+	var augment func(u Label) bool
+	augment = func(u Label) bool {
+		for _, v := range nbrs[u] {
+			w, ok := mate[v]
+			if !ok && dist[u]+1 == free || ok && dist[w] == dist[u]+1 && augment(w) {
+				mate[u], mate[v] = v, u
+				return true
+			}
+		}
+		// u leads to no augmenting path in this phase
+		dist[u] = infinity
+		return false
+	}
+	for levels() {
+		for _, u := range left {
+			if _, ok := mate[u]; !ok {
+				augment(u)
+			}
+		}
+	}
+	return mate, nil
+}
+
+// Hungarian solves the assignment problem for a rows x cols cost
+// matrix with rows <= cols, assigning each row i a distinct column
+// j so that the sum of the costs cost(i, j) is least
+// Rows are added one at a time, and each is assigned by finding a
+// shortest augmenting path in the costs reduced by the potentials u
+// of the rows and v of the columns, which are then adjusted to keep
+// every reduced cost nonnegative and those of assigned cells 0
+// It returns the column assigned to each row and the total cost,
+// or ErrNoAssignment if there are more rows than columns
+// This is achieved in an order 0(rows² cols)-time
+func Hungarian(cost *matrix.Matrix) ([]int, float64, error) {
+	n, m := cost.Rows(), cost.Cols()
+	if n > m {
+		return nil, 0, ErrNoAssignment
+	}
+	c := cost.Arrays()
+	// row p[j] is assigned column j, with rows and
+	// columns numbered from 1 and column 0 a sentinel
+	u := make([]float64, n+1)
+	v := make([]float64, m+1)
+	p := make([]int, m+1)
+	way := make([]int, m+1)
+	for i := 1; i <= n; i++ {
+		p[0] = i
+		j0 := 0
+		minv := make([]float64, m+1)
+		for j := range minv {
+			minv[j] = math.Inf(1)
+		}
+		used := make([]bool, m+1)
+		for p[j0] != 0 {
+			used[j0] = true
+			i0, delta, j1 := p[j0], math.Inf(1), 0
+			for j := 1; j <= m; j++ {
+				if used[j] {
+					continue
+				}
+				if r := c[i0-1][j-1] - u[i0] - v[j]; r < minv[j] {
+					minv[j], way[j] = r, j0
+				}
+				if minv[j] < delta {
+					delta, j1 = minv[j], j
+				}
+			}
+			for j := 0; j <= m; j++ {
+				if used[j] {
+					u[p[j]] += delta
+					v[j] -= delta
+				} else {
+					minv[j] -= delta
+				}
+			}
+			j0 = j1
+		}
+		for j0 != 0 {
+			j1 := way[j0]
+			p[j0] = p[j1]
+			j0 = j1
+		}
+	}
+	assignment := make([]int, n)
+	var total float64
+	for j := 1; j <= m; j++ {
+		if p[j] != 0 {
+			assignment[p[j]-1] = j - 1
+			total += c[p[j]-1][j-1]
+		}
+	}
+	return assignment, total, nil
+}
+
+// Assignment assigns each of the workers a distinct job, the jobs
+// being the other vertices joined to the workers by an edge of G,
+// regardless of its direction, whose weight is the cost of the job
+// to the worker, so that the total cost is least
+// It builds the cost matrix of workers by jobs, in sorted order,
+// for Hungarian, pricing a pair with no edge higher than any
+// assignment that only uses edges could cost
+// It returns the job of each worker and the total cost, or
+// ErrNoAssignment if there is no assignment using only edges of G
+// This is achieved in an order 0(W² J + E)-time for W workers and J jobs
+func Assignment(G *Graph, workers []Label) (map[Label]Label, int, error) {
+	isWorker := make(map[Label]bool, len(workers))
+	for _, w := range workers {
+		if _, ok := G.V[w]; !ok {
+			return nil, 0, ErrVertexNotFound
+		}
+		isWorker[w] = true
+	}
+	nbrs := G.neighbors()
+	index := make(map[Label]int)
+	var jobs []Label
+	var bound int
+	for _, w := range workers {
+		for _, j := range nbrs[w] {
+			if _, ok := index[j]; !ok && !isWorker[j] {
+				index[j] = len(jobs)
+				jobs = append(jobs, j)
+			}
+			if c := G.E[G.edgeBetween(w, j)]; c < 0 {
+				bound -= c
+			} else {
+				bound += c
+			}
+		}
+	}
+	if len(jobs) < len(workers) {
+		return nil, 0, ErrNoAssignment
+	}
+	sortLabels(jobs)
+	for i, j := range jobs {
+		index[j] = i
+	}
+	// an assignment using a missing pair costs more than missing
+	// less bound, which is more than any assignment using edges
+	missing := float64(2*bound + 1)
+	cost := matrix.NewMatrix(len(workers), len(jobs))
+	cost.Fill(missing)
+	for i, w := range workers {
+		for _, j := range nbrs[w] {
+			if !isWorker[j] {
+				cost.Set(i, index[j], float64(G.E[G.edgeBetween(w, j)]))
+			}
+		}
+	}
+	assignment, _, err := Hungarian(cost)
+	if err != nil {
+		return nil, 0, err
+	}
+	jobOf := make(map[Label]Label, len(workers))
+	var total int
+	for i, w := range workers {
+		j := jobs[assignment[i]]
+		if !G.hasEdge(w, j) && !G.hasEdge(j, w) {
+			return nil, 0, ErrNoAssignment
+		}
+		jobOf[w] = j
+		total += G.E[G.edgeBetween(w, j)]
+	}
+	return jobOf, total, nil
+}
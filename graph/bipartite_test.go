@@ -0,0 +1,153 @@
+package graph
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/willpoint/algor/matrix"
+)
+
+// clrsMatching is the bipartite graph of figure 26.8 in CLRS
+var clrsMatching = [][2]string{
+	{"l1", "r1"}, {"l2", "r1"}, {"l2", "r3"}, {"l3", "r2"},
+	{"l3", "r3"}, {"l3", "r4"}, {"l4", "r3"}, {"l5", "r3"},
+}
+
+func TestBipartite(t *testing.T) {
+	G := BuildUndirectedGraph(clrsMatching)
+	side, err := Bipartite(G)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for e := range G.E {
+		if side[e.U.Label] == side[e.V.Label] {
+			t.Errorf("expected %s and %s on different sides", e.U.Label, e.V.Label)
+		}
+	}
+	if _, err := Bipartite(BuildGrid(4, 5, nil)); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	G = BuildGraph([][2]string{{"a", "b"}, {"b", "c"}, {"c", "d"}, {"d", "e"}, {"e", "a"}, {"e", "f"}})
+	_, err = Bipartite(G)
+	oc, ok := err.(*OddCycleError)
+	if !ok {
+		t.Fatalf("expected an odd cycle error, got %v", err)
+	}
+	if len(oc.Cycle) != 5 {
+		t.Errorf("expected a cycle of 5 vertices, got %v", oc)
+	}
+	for i, u := range oc.Cycle {
+		v := oc.Cycle[(i+1)%len(oc.Cycle)]
+		if !G.hasEdge(u, v) && !G.hasEdge(v, u) {
+			t.Errorf("expected %s and %s to be joined in %v", u, v, oc)
+		}
+	}
+	if _, err := Bipartite(BuildGraph([][2]string{{"a", "a"}})); err == nil {
+		t.Errorf("expected a self-loop to be an odd cycle")
+	}
+}
+
+func TestHopcroftKarp(t *testing.T) {
+	G := BuildUndirectedGraph(clrsMatching)
+	mate, err := HopcroftKarp(G)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(mate) != 6 {
+		t.Errorf("expected a matching of 3 edges, got %v", mate)
+	}
+	for u, v := range mate {
+		if mate[v] != u || !G.hasEdge(u, v) && !G.hasEdge(v, u) {
+			t.Errorf("expected %s and %s to be matched along an edge", u, v)
+		}
+	}
+	// a perfect matching of a grid with an even number of cells
+	mate, _ = HopcroftKarp(BuildGrid(6, 7, nil))
+	if len(mate) != 42 {
+		t.Errorf("expected a perfect matching of 21 edges, got %d", len(mate)/2)
+	}
+	if _, err := HopcroftKarp(BuildUndirectedGraph([][2]string{{"a", "b"}, {"b", "c"}, {"c", "a"}})); err == nil {
+		t.Errorf("expected an odd cycle error")
+	}
+}
+
+func TestHungarian(t *testing.T) {
+	cost := matrix.NewMatrix(3, 4)
+	for i, row := range [][]float64{
+		{4, 1, 3, 9},
+		{2, 0, 5, 9},
+		{3, 2, 2, 9},
+	} {
+		cost.SetRowSlice(i, row)
+	}
+	assignment, total, err := Hungarian(cost)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if total != 5 || assignment[0] != 1 || assignment[1] != 0 || assignment[2] != 2 {
+		t.Errorf("expected assignment [1 0 2] costing 5, got %v costing %v", assignment, total)
+	}
+	if _, _, err := Hungarian(cost.Transpose()); err != ErrNoAssignment {
+		t.Errorf("expected %v, got %v", ErrNoAssignment, err)
+	}
+}
+
+func TestAssignment(t *testing.T) {
+	G := BuildUndirectedWeightedGraph([]weightedPair{
+		{Pair: [2]string{"alice", "x"}, Weight: 4},
+		{Pair: [2]string{"alice", "y"}, Weight: 1},
+		{Pair: [2]string{"alice", "z"}, Weight: 3},
+		{Pair: [2]string{"bob", "x"}, Weight: 2},
+		{Pair: [2]string{"bob", "y"}, Weight: 0},
+		{Pair: [2]string{"carol", "y"}, Weight: 2},
+		{Pair: [2]string{"carol", "z"}, Weight: 2},
+	})
+	jobOf, total, err := Assignment(G, []Label{"alice", "bob", "carol"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if total != 5 || jobOf["alice"] != "y" || jobOf["bob"] != "x" || jobOf["carol"] != "z" {
+		t.Errorf("expected alice, bob and carol to do y, x and z costing 5, got %v costing %d", jobOf, total)
+	}
+	G.RemoveEdge("bob", "x")
+	G.RemoveEdge("carol", "z")
+	if _, _, err := Assignment(G, []Label{"alice", "bob", "carol"}); err != ErrNoAssignment {
+		t.Errorf("expected %v, got %v", ErrNoAssignment, err)
+	}
+}
+
+func TestHopcroftKarpMaxFlow(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		var pairs, flow [][2]string
+		n, m := r.Intn(8)+1, r.Intn(8)+1
+		for u := 0; u < n; u++ {
+			flow = append(flow, [2]string{"s", fmt.Sprintf("L%d", u)})
+		}
+		for v := 0; v < m; v++ {
+			flow = append(flow, [2]string{fmt.Sprintf("R%d", v), "t"})
+		}
+		for u := 0; u < n; u++ {
+			for v := 0; v < m; v++ {
+				if r.Intn(3) == 0 {
+					pair := [2]string{fmt.Sprintf("L%d", u), fmt.Sprintf("R%d", v)}
+					pairs = append(pairs, pair)
+					flow = append(flow, pair)
+				}
+			}
+		}
+		mate, err := HopcroftKarp(BuildUndirectedGraph(pairs))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		fl, err := EdmondsKarp(BuildGraph(flow), "s", "t")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(mate)/2 != fl.Value {
+			t.Fatalf("expected a matching of %d edges, got %d for %v", fl.Value, len(mate)/2, pairs)
+		}
+	}
+}
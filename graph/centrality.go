@@ -0,0 +1,211 @@
+package graph
+
+import (
+	"math"
+
+	"github.com/willpoint/algor/heap"
+)
+
+// maxRankIterations bounds the power iterations of PageRank
+const maxRankIterations = 1000
+
+// PageRank ranks the vertices of G by the stationary distribution of a
+// random surfer who, with probability damping, follows an edge leaving
+// the vertex they are at chosen uniformly at random, and otherwise
+// jumps to a vertex chosen uniformly at random, as they also do from a
+// vertex with no edges leaving it. Damping is commonly 0.85
+// The ranks, which add up to 1, are found by power iteration from the
+// uniform distribution until the total change of an iteration falls
+// below tol, or after maxRankIterations
+// This is achieved in an order 0(V + E)-time per iteration
+func PageRank(G *Graph, damping, tol float64) map[Label]float64 {
+	n := float64(G.VNum)
+	rank := make(map[Label]float64, len(G.V))
+	for l := range G.V {
+		rank[l] = 1 / n
+	}
+	for i := 0; i < maxRankIterations; i++ {
+		var dangling float64
+		for l, v := range G.V {
+			if len(v.Adj) == 0 {
+				dangling += rank[l]
+			}
+		}
+		next := make(map[Label]float64, len(G.V))
+		base := (1-damping)/n + damping*dangling/n
+		for l := range G.V {
+			next[l] = base
+		}
+		for l, v := range G.V {
+			share := damping * rank[l] / float64(len(v.Adj))
+			for _, j := range v.Adj {
+				next[j] += share
+			}
+		}
+		var change float64
+		for l := range G.V {
+			change += math.Abs(next[l] - rank[l])
+		}
+		rank = next
+		if change < tol {
+			break
+		}
+	}
+	return rank
+}
+
+// DegreeCentrality returns the fraction of the other vertices each
+// vertex of G is joined to, its degree over |V| - 1, where the degree
+// of a vertex of a directed graph is the sum of its in and out degrees
+func DegreeCentrality(G *Graph) map[Label]float64 {
+	c := make(map[Label]float64, len(G.V))
+	if G.VNum < 2 {
+		for l := range G.V {
+			c[l] = 0
+		}
+		return c
+	}
+	d := make(map[Label]int, len(G.V))
+	for e := range G.E {
+		d[e.U.Label]++
+		d[e.V.Label]++
+	}
+	for l := range G.V {
+		c[l] = float64(d[l]) / float64(G.VNum-1)
+	}
+	return c
+}
+
+// shortestPathDAG holds the shortest paths from a source s found by
+// Brandes' algorithm, Order lists the vertices reached in the order
+// they were settled, Pred the predecessors of each vertex on the
+// shortest paths to it and Sigma the number of shortest paths to it
+type shortestPathDAG struct {
+	Order []Label
+	Dist  map[Label]int
+	Pred  map[Label][]Label
+	Sigma map[Label]float64
+}
+
+// countPaths finds the shortest paths from s, by BFS counting edges
+// or by Dijkstra's algorithm on the weights of G, which have to be
+// positive for the shortest paths to form a DAG
+func countPaths(G *Graph, s Label, weighted bool) *shortestPathDAG {
+	d := &shortestPathDAG{
+		Dist:  map[Label]int{s: 0},
+		Pred:  make(map[Label][]Label),
+		Sigma: map[Label]float64{s: 1},
+	}
+	visit := func(u *Vertex, v Label, w int) bool {
+		dv, ok := d.Dist[v]
+		switch du := d.Dist[u.Label]; {
+		case !ok || du+w < dv:
+			d.Dist[v] = du + w
+			d.Sigma[v] = d.Sigma[u.Label]
+			d.Pred[v] = []Label{u.Label}
+			return true
+		case du+w == dv:
+			d.Sigma[v] += d.Sigma[u.Label]
+			d.Pred[v] = append(d.Pred[v], u.Label)
+		}
+		return false
+	}
+	if !weighted {
+		Q := []Label{s}
+		for len(Q) > 0 {
+			u := G.V[Q[0]]
+			Q = Q[1:]
+			d.Order = append(d.Order, u.Label)
+			for _, j := range u.Adj {
+				if visit(u, j, 1) {
+					Q = append(Q, j)
+				}
+			}
+		}
+		return d
+	}
+	Q := heap.NewIndexedMinPQ()
+	Q.Insert(s, 0)
+	for !Q.Empty() {
+		min, _, _ := Q.ExtractMin()
+		u := G.V[min.(Label)]
+		d.Order = append(d.Order, u.Label)
+		for _, j := range u.Adj {
+			if visit(u, j, G.weight(NewEdge(u, G.V[j]))) {
+				enqueue(Q, j, d.Dist[j])
+			}
+		}
+	}
+	return d
+}
+
+// Betweenness returns the betweenness centrality of each vertex v of
+// G, the sum over pairs of other vertices s and t of the fraction of
+// the shortest paths from s to t that go through v, where shortest
+// paths count edges, or add up weights when weighted is true, in
+// which case the weights have to be positive. Each pair of an
+// undirected graph is counted once
+// Brandes' algorithm finds the shortest paths from each source s and
+// then accumulates the dependency of s on each vertex v, going back
+// from the vertices farthest from s, as the sum over the successors
+// w of v on the shortest paths of σ(v) / σ(w) (1 + δ(w)), σ being the
+// number of shortest paths from s
+// This is achieved in an order 0(VE)-time, or 0(VE + V² lg V)-time
+// when weighted
+func Betweenness(G *Graph, weighted bool) map[Label]float64 {
+	c := make(map[Label]float64, len(G.V))
+	for l := range G.V {
+		c[l] = 0
+	}
+	for s := range G.V {
+		d := countPaths(G, s, weighted)
+		delta := make(map[Label]float64, len(d.Order))
+		for i := len(d.Order) - 1; i >= 0; i-- {
+			w := d.Order[i]
+			for _, v := range d.Pred[w] {
+				delta[v] += d.Sigma[v] / d.Sigma[w] * (1 + delta[w])
+			}
+			if w != s {
+				c[w] += delta[w]
+			}
+		}
+	}
+	if G.Undirected {
+		for l := range c {
+			c[l] /= 2
+		}
+	}
+	return c
+}
+
+// Closeness returns the closeness centrality of each vertex u of G,
+// the number r - 1 of other vertices reachable from u over the sum of
+// their distances from u, scaled by (r - 1) / (|V| - 1) so that a
+// vertex reaching few vertices is not deemed central, where distances
+// count edges, or add up weights when weighted is true, in which
+// case the weights have to be nonnegative
+// A vertex reaching no other vertex has a closeness of 0
+// This is achieved in an order 0(V(V + E))-time, or
+// 0(V(V + E) lg V)-time when weighted
+func Closeness(G *Graph, weighted bool) map[Label]float64 {
+	c := make(map[Label]float64, len(G.V))
+	for l := range G.V {
+		var p *Paths
+		if weighted {
+			p, _ = Dijkstra(G, l)
+		} else {
+			p, _ = BFS(G, l)
+		}
+		var sum int
+		for _, d := range p.Distance {
+			sum += d
+		}
+		r := float64(len(p.Distance) - 1)
+		if r == 0 || sum == 0 {
+			c[l] = 0
+			continue
+		}
+		c[l] = r / float64(sum) * r / float64(G.VNum-1)
+	}
+	return c
+}
@@ -0,0 +1,94 @@
+package graph
+
+import (
+	"math"
+	"testing"
+)
+
+func near(x, y float64) bool {
+	return math.Abs(x-y) < 1e-6
+}
+
+func checkScores(t *testing.T, name string, got, expected map[Label]float64) {
+	t.Helper()
+	for l, x := range expected {
+		if !near(got[l], x) {
+			t.Errorf("%s: expected %s to score %v, got %v", name, l, x, got[l])
+		}
+	}
+}
+
+func TestPageRank(t *testing.T) {
+	cycle := BuildGraph([][2]string{{"a", "b"}, {"b", "c"}, {"c", "a"}})
+	checkScores(t, "cycle", PageRank(cycle, 0.85, 1e-10), map[Label]float64{
+		"a": 1.0 / 3, "b": 1.0 / 3, "c": 1.0 / 3,
+	})
+	// b has no edges leaving it, its rank is spread over both
+	pair := BuildGraph([][2]string{{"a", "b"}})
+	checkScores(t, "pair", PageRank(pair, 0.85, 1e-10), map[Label]float64{
+		"a": 0.5 / 1.425, "b": 1 - 0.5/1.425,
+	})
+	G := BuildGraph(clrsSCC)
+	rank := PageRank(G, 0.85, 1e-10)
+	var sum float64
+	for _, r := range rank {
+		sum += r
+	}
+	if !near(sum, 1) {
+		t.Errorf("expected the ranks to add up to 1, got %v", sum)
+	}
+}
+
+func TestDegreeCentrality(t *testing.T) {
+	G := BuildUndirectedGraph([][2]string{{"a", "b"}, {"b", "c"}})
+	checkScores(t, "undirected", DegreeCentrality(G), map[Label]float64{
+		"a": 0.5, "b": 1, "c": 0.5,
+	})
+	D := BuildGraph([][2]string{{"a", "b"}, {"b", "a"}, {"b", "c"}})
+	checkScores(t, "directed", DegreeCentrality(D), map[Label]float64{
+		"a": 1, "b": 1.5, "c": 0.5,
+	})
+}
+
+func TestBetweenness(t *testing.T) {
+	path := BuildUndirectedGraph([][2]string{{"a", "b"}, {"b", "c"}, {"c", "d"}, {"d", "e"}})
+	checkScores(t, "path", Betweenness(path, false), map[Label]float64{
+		"a": 0, "b": 3, "c": 4, "d": 3, "e": 0,
+	})
+	chain := BuildGraph([][2]string{{"a", "b"}, {"b", "c"}})
+	checkScores(t, "directed", Betweenness(chain, false), map[Label]float64{
+		"a": 0, "b": 1, "c": 0,
+	})
+	// a square with a heavy diagonal, which is only
+	// a shortest path when weights are ignored
+	square := BuildUndirectedWeightedGraph([]weightedPair{
+		{Pair: [2]string{"a", "b"}, Weight: 1},
+		{Pair: [2]string{"b", "c"}, Weight: 1},
+		{Pair: [2]string{"c", "d"}, Weight: 1},
+		{Pair: [2]string{"d", "a"}, Weight: 1},
+		{Pair: [2]string{"a", "c"}, Weight: 5},
+	})
+	checkScores(t, "unweighted", Betweenness(square, false), map[Label]float64{
+		"a": 0.5, "b": 0, "c": 0.5, "d": 0,
+	})
+	checkScores(t, "weighted", Betweenness(square, true), map[Label]float64{
+		"a": 0.5, "b": 0.5, "c": 0.5, "d": 0.5,
+	})
+}
+
+func TestCloseness(t *testing.T) {
+	G := BuildUndirectedWeightedGraph([]weightedPair{
+		{Pair: [2]string{"a", "b"}, Weight: 1},
+		{Pair: [2]string{"b", "c"}, Weight: 3},
+	})
+	checkScores(t, "unweighted", Closeness(G, false), map[Label]float64{
+		"a": 2.0 / 3, "b": 1, "c": 2.0 / 3,
+	})
+	checkScores(t, "weighted", Closeness(G, true), map[Label]float64{
+		"a": 2.0 / 5, "b": 2.0 / 4, "c": 2.0 / 7,
+	})
+	G.AddVertex("d")
+	checkScores(t, "disconnected", Closeness(G, false), map[Label]float64{
+		"a": 2.0 / 3 * 2 / 3, "b": 2.0 / 3, "d": 0,
+	})
+}
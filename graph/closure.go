@@ -0,0 +1,128 @@
+package graph
+
+import (
+	"sort"
+	"strconv"
+)
+
+// bitset is a set of small nonnegative integers
+type bitset []uint64
+
+func newBitset(n int) bitset {
+	return make(bitset, (n+63)/64)
+}
+
+func (b bitset) add(i int)      { b[i/64] |= 1 << uint(i%64) }
+func (b bitset) has(i int) bool { return b[i/64]&(1<<uint(i%64)) != 0 }
+
+// union adds the members of c to b
+func (b bitset) union(c bitset) {
+	for i := range c {
+		b[i] |= c[i]
+	}
+}
+
+// TransitiveClosure returns the transitive closure of G, a new graph
+// on the vertices of G holding an edge (u, v) of weight 1 whenever v
+// is reachable from u by a path of at least one edge, so that (u, u)
+// is an edge when u lies on a cycle. The closure of an undirected
+// graph joins every two vertices of each of its connected components
+// Reachability is found on the component graph of G, going through
+// its components in reverse topological order and giving each the
+// union of the components reachable from its successors as a bitset
+// This is achieved in an order 0(V² + E·V/64)-time
+func TransitiveClosure(G *Graph) *Graph {
+	D, C := Condensation(G)
+	n := D.VNum
+	members := make([][]Label, n)
+	cyclic := make([]bool, n)
+	for _, l := range G.labels() {
+		i, _ := strconv.Atoi(string(C[l]))
+		members[i] = append(members[i], l)
+		if _, ok := G.Weight(l, l); ok || len(members[i]) > 1 {
+			cyclic[i] = true
+		}
+	}
+	reach := make([]bitset, n)
+	for i := n - 1; i >= 0; i-- {
+		reach[i] = newBitset(n)
+		if cyclic[i] {
+			reach[i].add(i)
+		}
+		for _, j := range D.V[Label(strconv.Itoa(i))].Adj {
+			k, _ := strconv.Atoi(string(j))
+			reach[i].add(k)
+			reach[i].union(reach[k])
+		}
+	}
+	T := NewGraph()
+	T.Undirected = G.Undirected
+	for _, l := range G.labels() {
+		T.AddVertex(l)
+	}
+	for i := 0; i < n; i++ {
+		for k := 0; k < n; k++ {
+			if !reach[i].has(k) {
+				continue
+			}
+			for _, u := range members[i] {
+				for _, v := range members[k] {
+					// the reverse of an undirected edge
+					// already added is refused as a duplicate
+					T.AddEdge(u, v, 1)
+				}
+			}
+		}
+	}
+	return T
+}
+
+// TransitiveReduction returns the transitive reduction of a DAG G,
+// a new graph with the fewest edges of G that leaves the same pairs
+// of vertices reachable from one another. It keeps an edge (u, v),
+// with its weight, unless v is also reachable from u through another
+// successor of u. The successors of each vertex are visited in
+// topological order, so that one reachable through another is met
+// after it, and the vertices in reverse order, so that the bitset of
+// the vertices reachable from each successor is known
+// It returns a *CycleError if G has a cycle, whose reduction is not
+// unique, and ErrUndirected if G is undirected
+// This is achieved in an order 0(V·E/64 + E lg V)-time
+func TransitiveReduction(G *Graph) (*Graph, error) {
+	if G.Undirected {
+		return nil, ErrUndirected
+	}
+	order, err := topoOrder(G)
+	if err != nil {
+		return nil, err
+	}
+	n := len(order)
+	index := make(map[Label]int, n)
+	for i, l := range order {
+		index[l] = i
+	}
+	R := NewGraph()
+	for _, l := range G.labels() {
+		R.AddVertex(l)
+	}
+	reach := make([]bitset, n)
+	for i := n - 1; i >= 0; i-- {
+		reach[i] = newBitset(n)
+		u := G.V[order[i]]
+		succ := make([]int, 0, len(u.Adj))
+		for _, j := range u.Adj {
+			succ = append(succ, index[j])
+		}
+		sort.Ints(succ)
+		for _, k := range succ {
+			if reach[i].has(k) {
+				continue
+			}
+			v := order[k]
+			R.AddEdge(u.Label, v, G.E[NewEdge(u, G.V[v])])
+			reach[i].add(k)
+			reach[i].union(reach[k])
+		}
+	}
+	return R, nil
+}
@@ -0,0 +1,77 @@
+package graph
+
+import "testing"
+
+func TestTransitiveClosure(t *testing.T) {
+	G := BuildGraph(clrsSCC)
+	T := TransitiveClosure(G)
+	consistent(t, T)
+	for _, u := range G.labels() {
+		p, _ := BFS(G, u)
+		for _, v := range G.labels() {
+			// u reaches itself by a nonempty path only on a cycle
+			reached := p.Reached(v) && u != v
+			if u == v {
+				for _, w := range G.V[u].Adj {
+					if q, _ := BFS(G, w); q.Reached(u) {
+						reached = true
+					}
+				}
+			}
+			if _, ok := T.Weight(u, v); ok != reached {
+				t.Errorf("expected (%s, %s) in the closure to be %v", u, v, reached)
+			}
+		}
+	}
+
+	D := TransitiveClosure(BuildGraph(clrsDress))
+	if _, ok := D.Weight("undershorts", "shoes"); !ok {
+		t.Errorf("expected shoes to depend on undershorts")
+	}
+	if _, ok := D.Weight("shoes", "undershorts"); ok {
+		t.Errorf("expected undershorts not to depend on shoes")
+	}
+	if _, ok := D.Weight("watch", "watch"); ok {
+		t.Errorf("expected no self-loop on watch")
+	}
+
+	U := TransitiveClosure(BuildUndirectedGraph([][2]string{{"a", "b"}, {"b", "c"}, {"x", "y"}, {"z", ""}}))
+	if !U.Undirected || U.ENum != 3+3+1+2 {
+		t.Errorf("expected 9 undirected edges, got %d", U.ENum)
+	}
+}
+
+func TestTransitiveReduction(t *testing.T) {
+	G := BuildWeightedGraph([]weightedPair{
+		{Pair: [2]string{"a", "b"}, Weight: 2},
+		{Pair: [2]string{"a", "c"}, Weight: 1},
+		{Pair: [2]string{"a", "d"}, Weight: 1},
+		{Pair: [2]string{"a", "e"}, Weight: 1},
+		{Pair: [2]string{"b", "d"}, Weight: 3},
+		{Pair: [2]string{"c", "d"}, Weight: 4},
+		{Pair: [2]string{"c", "e"}, Weight: 1},
+		{Pair: [2]string{"d", "e"}, Weight: 5},
+	})
+	R, err := TransitiveReduction(G)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	consistent(t, R)
+	expected := map[[2]Label]int{
+		{"a", "b"}: 2, {"a", "c"}: 1, {"b", "d"}: 3, {"c", "d"}: 4, {"d", "e"}: 5,
+	}
+	if R.ENum != len(expected) {
+		t.Errorf("expected %d edges, got %d", len(expected), R.ENum)
+	}
+	for e, w := range expected {
+		if x, ok := R.Weight(e[0], e[1]); !ok || x != w {
+			t.Errorf("expected (%s, %s) of weight %d in the reduction", e[0], e[1], w)
+		}
+	}
+	if C, D := TransitiveClosure(G), TransitiveClosure(R); C.ENum != D.ENum {
+		t.Errorf("expected the reduction to have the same closure")
+	}
+	if _, err := TransitiveReduction(BuildGraph(clrsSCC)); err == nil {
+		t.Errorf("expected a cycle error")
+	}
+}
@@ -0,0 +1,155 @@
+package graph
+
+// Schedule holds the critical path analysis of a DAG whose vertices
+// are events and whose edges are activities taking as long as their
+// weight, where an event happens once all activities into it are done
+// Earliest and Latest hold the earliest and latest times an event can
+// happen without delaying the project, which starts at time 0, and
+// Slack the difference between the two, which is 0 for every event
+// on Path, a critical path - a longest path in the DAG whose weight
+// is the least time needed to complete the project
+type Schedule struct {
+	Path     *Path
+	Earliest map[Label]int
+	Latest   map[Label]int
+	Slack    map[Label]int
+}
+
+// topoOrder returns the labels of G in the order of TopoSort
+func topoOrder(G *Graph) ([]Label, error) {
+	l, err := TopoSort(G)
+	if err != nil {
+		return nil, err
+	}
+	order := make([]Label, 0, l.Length)
+	for n := l.Head; n != nil; n = n.Next {
+		order = append(order, Label(n.E))
+	}
+	return order, nil
+}
+
+// DAGShortestPaths solves the single-source shortest-paths problem
+// on a weighted DAG G, in which edge weights may be negative, by
+// relaxing the edges leaving each vertex in topologically sorted
+// order, so that every edge into a vertex is relaxed before any edge
+// leaving it. It returns a *CycleError if G has a cycle, or
+// ErrUndirected if G is undirected
+// This is achieved in an order 0(V + E)-time
+func DAGShortestPaths(G *Graph, src Label) (*Paths, error) {
+	return dagPaths(G, src, (*Paths).relax)
+}
+
+// DAGLongestPaths finds the weights of longest paths from src to
+// every vertex reachable from it in a weighted DAG G, in the manner
+// of DAGShortestPaths but keeping the larger estimate on relaxation
+// It returns a *CycleError if G has a cycle, or ErrUndirected if G
+// is undirected
+// This is achieved in an order 0(V + E)-time
+func DAGLongestPaths(G *Graph, src Label) (*Paths, error) {
+	return dagPaths(G, src, (*Paths).stretch)
+}
+
+func dagPaths(G *Graph, src Label, relax func(*Paths, *Graph, Edge) bool) (*Paths, error) {
+	if _, ok := G.V[src]; !ok {
+		return nil, ErrVertexNotFound
+	}
+	order, err := topoOrder(G)
+	if err != nil {
+		return nil, err
+	}
+	p := initSingleSource(src)
+	for _, l := range order {
+		u := G.V[l]
+		for _, j := range u.Adj {
+			relax(p, G, NewEdge(u, G.V[j]))
+		}
+	}
+	return p, nil
+}
+
+// stretch is the inverse of relax, it reports whether the estimate of
+// v was raised by going through u, as for the longest paths of a DAG
+func (p *Paths) stretch(G *Graph, e Edge) bool {
+	du, ok := p.Distance[e.U.Label]
+	if !ok {
+		return false
+	}
+	w := G.weight(e)
+	dv, ok := p.Distance[e.V.Label]
+	improved := !ok || dv < du+w
+	if improved {
+		p.Distance[e.V.Label] = du + w
+		p.Predecessor[e.V.Label] = e.U.Label
+	}
+	return improved
+}
+
+// CriticalPath computes the Schedule of a weighted DAG G
+// The earliest time of each event is the weight of a longest path
+// into it, found by stretching the edges in topologically sorted
+// order from every event at once, and its latest time is the least of
+// the latest times of its successors less the activities leading to
+// them, found by going over the events in reverse order, where an
+// event with no successors may happen as late as the project ends
+// It returns a *CycleError if G has a cycle, or ErrUndirected if G
+// is undirected
+// This is achieved in an order 0(V + E)-time
+func CriticalPath(G *Graph) (*Schedule, error) {
+	order, err := topoOrder(G)
+	if err != nil {
+		return nil, err
+	}
+	p := &Paths{
+		Distance:    make(map[Label]int, len(order)),
+		Predecessor: make(map[Label]Label),
+	}
+	for _, l := range order {
+		p.Distance[l] = 0
+	}
+	for _, l := range order {
+		u := G.V[l]
+		for _, j := range u.Adj {
+			p.stretch(G, NewEdge(u, G.V[j]))
+		}
+	}
+	s := &Schedule{
+		Path:     &Path{},
+		Earliest: p.Distance,
+		Latest:   make(map[Label]int, len(order)),
+		Slack:    make(map[Label]int, len(order)),
+	}
+	if len(order) == 0 {
+		return s, nil
+	}
+	end := order[0]
+	for _, l := range order {
+		if s.Earliest[l] > s.Earliest[end] {
+			end = l
+		}
+	}
+	length := s.Earliest[end]
+	for i := len(order) - 1; i >= 0; i-- {
+		u := G.V[order[i]]
+		latest := length
+		for _, j := range u.Adj {
+			if t := s.Latest[j] - G.weight(NewEdge(u, G.V[j])); t < latest {
+				latest = t
+			}
+		}
+		s.Latest[u.Label] = latest
+		s.Slack[u.Label] = latest - s.Earliest[u.Label]
+	}
+	labels := []Label{end}
+	for v, ok := p.Predecessor[end]; ok; v, ok = p.Predecessor[v] {
+		labels = append(labels, v)
+	}
+	for i, j := 0, len(labels)-1; i < j; i, j = i+1, j-1 {
+		labels[i], labels[j] = labels[j], labels[i]
+	}
+	s.Path = &Path{
+		Labels: labels,
+		Weight: length,
+		Hops:   len(labels) - 1,
+	}
+	return s, nil
+}
@@ -0,0 +1,90 @@
+package graph
+
+import "testing"
+
+// clrsDAG is the graph of figure 24.5 in CLRS
+var clrsDAG = []weightedPair{
+	{Pair: [2]string{"r", "s"}, Weight: 5},
+	{Pair: [2]string{"r", "t"}, Weight: 3},
+	{Pair: [2]string{"s", "t"}, Weight: 2},
+	{Pair: [2]string{"s", "x"}, Weight: 6},
+	{Pair: [2]string{"t", "x"}, Weight: 7},
+	{Pair: [2]string{"t", "y"}, Weight: 4},
+	{Pair: [2]string{"t", "z"}, Weight: 2},
+	{Pair: [2]string{"x", "y"}, Weight: -1},
+	{Pair: [2]string{"x", "z"}, Weight: 1},
+	{Pair: [2]string{"y", "z"}, Weight: -2},
+}
+
+func TestDAGPaths(t *testing.T) {
+	G := BuildWeightedGraph(clrsDAG)
+	for name, tc := range map[string]struct {
+		fn       func(*Graph, Label) (*Paths, error)
+		expected map[Label]int
+		path     string
+	}{
+		"shortest": {DAGShortestPaths, map[Label]int{"s": 0, "t": 2, "x": 6, "y": 5, "z": 3}, "s -> x -> y -> z"},
+		"longest":  {DAGLongestPaths, map[Label]int{"s": 0, "t": 2, "x": 9, "y": 8, "z": 10}, "s -> t -> x -> z"},
+	} {
+		p, err := tc.fn(G, "s")
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+		if p.Reached("r") {
+			t.Errorf("%s: expected r not to be reached", name)
+		}
+		for l, d := range tc.expected {
+			if p.Distance[l] != d {
+				t.Errorf("%s: expected distance of %s to be %d, got %d", name, l, d, p.Distance[l])
+			}
+		}
+		if path, _ := PathTo(p, "z"); path.String() != tc.path {
+			t.Errorf("%s: expected path %s, got %s", name, tc.path, path)
+		}
+	}
+	G.AddEdge("z", "r", 1)
+	if _, err := DAGShortestPaths(G, "s"); err == nil {
+		t.Errorf("expected a cycle error")
+	}
+}
+
+func TestCriticalPath(t *testing.T) {
+	G := BuildWeightedGraph([]weightedPair{
+		{Pair: [2]string{"s", "a"}, Weight: 3},
+		{Pair: [2]string{"s", "b"}, Weight: 2},
+		{Pair: [2]string{"a", "c"}, Weight: 4},
+		{Pair: [2]string{"b", "c"}, Weight: 1},
+		{Pair: [2]string{"b", "d"}, Weight: 6},
+		{Pair: [2]string{"c", "e"}, Weight: 2},
+		{Pair: [2]string{"d", "e"}, Weight: 2},
+	})
+	s, err := CriticalPath(G)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s.Path.String() != "s -> b -> d -> e" || s.Path.Weight != 10 {
+		t.Errorf("expected critical path s -> b -> d -> e of weight 10, got %s of weight %d", s.Path, s.Path.Weight)
+	}
+	if len(s.Earliest) != G.VNum || len(s.Latest) != G.VNum || len(s.Slack) != G.VNum {
+		t.Errorf("expected %d times of each kind, got %d, %d and %d", G.VNum, len(s.Earliest), len(s.Latest), len(s.Slack))
+	}
+	expected := map[Label][3]int{
+		"s": {0, 0, 0},
+		"a": {3, 4, 1},
+		"b": {2, 2, 0},
+		"c": {7, 8, 1},
+		"d": {8, 8, 0},
+		"e": {10, 10, 0},
+	}
+	for l, e := range expected {
+		if got := [3]int{s.Earliest[l], s.Latest[l], s.Slack[l]}; got != e {
+			t.Errorf("expected earliest, latest and slack of %s to be %v, got %v", l, e, got)
+		}
+	}
+	if s, _ := CriticalPath(NewGraph()); s.Path.Hops != 0 {
+		t.Errorf("expected an empty critical path, got %s", s.Path)
+	}
+	if _, err := CriticalPath(BuildUndirectedGraph([][2]string{{"a", "b"}})); err != ErrUndirected {
+		t.Errorf("expected %v, got %v", ErrUndirected, err)
+	}
+}
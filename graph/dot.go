@@ -0,0 +1,612 @@
+package graph
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// DOTOptions controls how WriteDOT draws a graph
+// Name is the ID given to the graph, "G" if empty.
+// The vertices and edges along Path, a sequence of labels such as
+// one returned for a shortest path, are highlighted.
+// Groups assigns vertices to groups that are each filled with their
+// own color, such as the index of the strongly connected component
+// of each vertex given by ComponentGroups, or the Distance of a BFS
+type DOTOptions struct {
+	Name   string
+	Path   []Label
+	Groups map[Label]int
+}
+
+// dotPalette holds the fill colors of the vertex groups
+var dotPalette = []string{
+	"lightblue", "lightpink", "palegreen", "khaki", "plum",
+	"lightsalmon", "paleturquoise", "wheat", "thistle", "lightgray",
+}
+
+// ComponentGroups returns the index of the component
+// of each vertex, for use as DOTOptions.Groups
+func ComponentGroups(components [][]Label) map[Label]int {
+	groups := make(map[Label]int)
+	for i, c := range components {
+		for _, l := range c {
+			groups[l] = i
+		}
+	}
+	return groups
+}
+
+// dotID quotes s as a DOT ID, escaping backslashes and quotes
+func dotID(s string) string {
+	s = strings.Replace(s, `\`, `\\`, -1)
+	return `"` + strings.Replace(s, `"`, `\"`, -1) + `"`
+}
+
+// WriteDOT writes G in the Graphviz DOT language to w, a directed
+// graph as a digraph and an undirected one as a graph. Vertices and
+// edges are written in order of their labels, and the weight of an
+// edge is written as its label unless it is the unit weight
+// opts may be nil
+func WriteDOT(w io.Writer, G *Graph, opts *DOTOptions) error {
+	if opts == nil {
+		opts = &DOTOptions{}
+	}
+	name := opts.Name
+	if name == "" {
+		name = "G"
+	}
+	kind, op := "digraph", "->"
+	if G.Undirected {
+		kind, op = "graph", "--"
+	}
+	onPath := make(map[Label]bool, len(opts.Path))
+	pathEdges := make(map[Edge]bool, len(opts.Path))
+	for i, l := range opts.Path {
+		onPath[l] = true
+		if i == 0 {
+			continue
+		}
+		if e, ok := G.edge(opts.Path[i-1], l); ok {
+			pathEdges[e] = true
+		}
+	}
+	b := bufio.NewWriter(w)
+	fmt.Fprintf(b, "%s %s {\n", kind, dotID(name))
+	for _, l := range G.labels() {
+		var attrs []string
+		if g, ok := opts.Groups[l]; ok {
+			if g < 0 {
+				g = -g
+			}
+			attrs = append(attrs, "style=filled", "fillcolor="+dotPalette[g%len(dotPalette)])
+		}
+		if onPath[l] {
+			attrs = append(attrs, "color=red", "penwidth=2")
+		}
+		fmt.Fprintf(b, "\t%s%s;\n", dotID(string(l)), dotAttrs(attrs))
+	}
+	edges := make([]Edge, 0, len(G.E))
+	for e := range G.E {
+		edges = append(edges, e)
+	}
+	sortEdges(edges)
+	for _, e := range edges {
+		var attrs []string
+		if wt := G.E[e]; wt != 1 {
+			attrs = append(attrs, "label="+dotID(strconv.Itoa(wt)))
+		}
+		if pathEdges[e] {
+			attrs = append(attrs, "color=red", "penwidth=2")
+		}
+		fmt.Fprintf(b, "\t%s %s %s%s;\n", dotID(string(e.U.Label)), op, dotID(string(e.V.Label)), dotAttrs(attrs))
+	}
+	fmt.Fprintln(b, "}")
+	return b.Flush()
+}
+
+// dotAttrs formats an attribute list
+func dotAttrs(attrs []string) string {
+	if len(attrs) == 0 {
+		return ""
+	}
+	return " [" + strings.Join(attrs, ", ") + "]"
+}
+
+// dot tokens
+const (
+	dotEOF = iota
+	dotIdent
+	dotPunct
+)
+
+type dotToken struct {
+	kind int
+	text string
+	line int
+}
+
+// dotLexer splits DOT source into IDs and punctuation,
+// skipping white space and comments
+type dotLexer struct {
+	r    *bufio.Reader
+	line int
+	peek *dotToken
+}
+
+func (lx *dotLexer) read() (rune, bool) {
+	c, _, err := lx.r.ReadRune()
+	if err != nil {
+		return 0, false
+	}
+	if c == '\n' {
+		lx.line++
+	}
+	return c, true
+}
+
+func (lx *dotLexer) unread(c rune) {
+	lx.r.UnreadRune()
+	if c == '\n' {
+		lx.line--
+	}
+}
+
+func (lx *dotLexer) errorf(format string, a ...interface{}) error {
+	return fmt.Errorf("dot: line %d: %s", lx.line, fmt.Sprintf(format, a...))
+}
+
+// next returns the next token
+func (lx *dotLexer) next() (dotToken, error) {
+	if lx.peek != nil {
+		t := *lx.peek
+		lx.peek = nil
+		return t, nil
+	}
+	for {
+		c, ok := lx.read()
+		if !ok {
+			return dotToken{kind: dotEOF, line: lx.line}, nil
+		}
+		switch {
+		case unicode.IsSpace(c):
+		case c == '#':
+			lx.skipLine()
+		case c == '/':
+			d, _ := lx.read()
+			switch d {
+			case '/':
+				lx.skipLine()
+			case '*':
+				if err := lx.skipComment(); err != nil {
+					return dotToken{}, err
+				}
+			default:
+				return dotToken{}, lx.errorf("unexpected %q", c)
+			}
+		case c == '"':
+			return lx.quoted()
+		case c == '<':
+			return lx.html()
+		case c == '-':
+			d, _ := lx.read()
+			if d == '>' || d == '-' {
+				return dotToken{kind: dotPunct, text: string([]rune{c, d}), line: lx.line}, nil
+			}
+			lx.unread(d)
+			return lx.ident(c)
+		case strings.ContainsRune("{}[]=;,:", c):
+			return dotToken{kind: dotPunct, text: string(c), line: lx.line}, nil
+		case c == '_' || c == '.' || unicode.IsLetter(c) || unicode.IsDigit(c):
+			return lx.ident(c)
+		default:
+			return dotToken{}, lx.errorf("unexpected %q", c)
+		}
+	}
+}
+
+func (lx *dotLexer) skipLine() {
+	for c, ok := lx.read(); ok && c != '\n'; c, ok = lx.read() {
+	}
+}
+
+func (lx *dotLexer) skipComment() error {
+	var star bool
+	for {
+		c, ok := lx.read()
+		if !ok {
+			return lx.errorf("unterminated comment")
+		}
+		if star && c == '/' {
+			return nil
+		}
+		star = c == '*'
+	}
+}
+
+// quoted reads a quoted string, where \" stands for " and \\ for \,
+// and any other character is taken as is
+func (lx *dotLexer) quoted() (dotToken, error) {
+	line := lx.line
+	var s []rune
+	for {
+		c, ok := lx.read()
+		if !ok {
+			return dotToken{}, lx.errorf("unterminated string")
+		}
+		if c == '"' {
+			return dotToken{kind: dotIdent, text: string(s), line: line}, nil
+		}
+		if c == '\\' {
+			if d, _ := lx.read(); d == '"' || d == '\\' {
+				c = d
+			} else {
+				lx.unread(d)
+			}
+		}
+		s = append(s, c)
+	}
+}
+
+// html reads an HTML string, taking the markup between
+// the outermost angle brackets as the ID
+func (lx *dotLexer) html() (dotToken, error) {
+	line := lx.line
+	var s []rune
+	depth := 1
+	for {
+		c, ok := lx.read()
+		if !ok {
+			return dotToken{}, lx.errorf("unterminated HTML string")
+		}
+		switch c {
+		case '<':
+			depth++
+		case '>':
+			depth--
+		}
+		if depth == 0 {
+			return dotToken{kind: dotIdent, text: string(s), line: line}, nil
+		}
+		s = append(s, c)
+	}
+}
+
+// ident reads an unquoted ID or numeral
+func (lx *dotLexer) ident(c rune) (dotToken, error) {
+	s := []rune{c}
+	for {
+		c, ok := lx.read()
+		if !ok {
+			break
+		}
+		if c != '_' && c != '.' && !unicode.IsLetter(c) && !unicode.IsDigit(c) {
+			lx.unread(c)
+			break
+		}
+		s = append(s, c)
+	}
+	return dotToken{kind: dotIdent, text: string(s), line: lx.line}, nil
+}
+
+// dotParser builds a Graph from DOT statements
+type dotParser struct {
+	lx *dotLexer
+	G  *Graph
+	op string
+	// edge holds the attributes set by edge statements in the
+	// current scope, which every edge after them starts from
+	edge map[string]string
+}
+
+// expect reads the next token and fails unless it is the punctuation p
+func (ps *dotParser) expect(p string) error {
+	t, err := ps.lx.next()
+	if err != nil {
+		return err
+	}
+	if t.kind != dotPunct || t.text != p {
+		return ps.lx.errorf("expected %q, found %q", p, t.text)
+	}
+	return nil
+}
+
+// ParseDOT reads a graph written in the Graphviz DOT language from r,
+// a digraph giving a directed Graph and a graph an undirected one.
+// Node statements add vertices and edge statements, including chains
+// such as a -> b -> c, add edges whose weight is taken from their
+// weight attribute, or from their label if it is an integer, and
+// is 1 otherwise. An edge given more than once takes the last weight.
+// Statements inside subgraphs are added to the graph, and a subgraph
+// used as an edge endpoint, as in a -> {b c}, stands for every vertex
+// in it. HTML strings such as <<b>x</b>> are read as IDs holding the
+// markup between the outer angle brackets. An edge attribute statement
+// such as edge [weight=5] sets the attributes of the edges after it up
+// to the end of its subgraph, while graph and node attribute
+// statements and other attributes are ignored
+func ParseDOT(r io.Reader) (*Graph, error) {
+	ps := &dotParser{lx: &dotLexer{r: bufio.NewReader(r), line: 1}}
+	t, err := ps.lx.next()
+	if err != nil {
+		return nil, err
+	}
+	if t.kind == dotIdent && strings.EqualFold(t.text, "strict") {
+		if t, err = ps.lx.next(); err != nil {
+			return nil, err
+		}
+	}
+	switch {
+	case t.kind == dotIdent && strings.EqualFold(t.text, "digraph"):
+		ps.G, ps.op = NewGraph(), "->"
+	case t.kind == dotIdent && strings.EqualFold(t.text, "graph"):
+		ps.G, ps.op = NewUndirectedGraph(), "--"
+	default:
+		return nil, ps.lx.errorf("expected graph or digraph, found %q", t.text)
+	}
+	if t, err = ps.lx.next(); err != nil {
+		return nil, err
+	}
+	if t.kind == dotIdent {
+		if t, err = ps.lx.next(); err != nil {
+			return nil, err
+		}
+	}
+	if t.kind != dotPunct || t.text != "{" {
+		return nil, ps.lx.errorf("expected %q, found %q", "{", t.text)
+	}
+	if _, err := ps.stmtList(); err != nil {
+		return nil, err
+	}
+	return ps.G, nil
+}
+
+// isSubgraph reports whether t starts a subgraph
+func isSubgraph(t dotToken) bool {
+	return t.kind == dotPunct && t.text == "{" ||
+		t.kind == dotIdent && strings.EqualFold(t.text, "subgraph")
+}
+
+// stmtList parses statements up to the closing brace
+// and returns the labels of the vertices they name
+func (ps *dotParser) stmtList() ([]Label, error) {
+	var labels []Label
+	for {
+		t, err := ps.lx.next()
+		if err != nil {
+			return nil, err
+		}
+		var ids []Label
+		switch {
+		case t.kind == dotEOF:
+			return nil, ps.lx.errorf("expected %q", "}")
+		case t.kind == dotPunct && t.text == "}":
+			return labels, nil
+		case t.kind == dotPunct && t.text == ";":
+		case isSubgraph(t):
+			if ids, err = ps.subgraph(t); err != nil {
+				return nil, err
+			}
+			n, err := ps.lx.next()
+			if err != nil {
+				return nil, err
+			}
+			if ids, err = ps.edgeStmt(ids, n); err != nil {
+				return nil, err
+			}
+		case t.kind == dotIdent:
+			if ids, err = ps.stmt(t); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, ps.lx.errorf("unexpected %q", t.text)
+		}
+		labels = append(labels, ids...)
+	}
+}
+
+// subgraph parses a subgraph starting with the token t, either
+// subgraph or the opening brace, and returns the labels of its vertices
+func (ps *dotParser) subgraph(t dotToken) ([]Label, error) {
+	var err error
+	if t.kind == dotIdent {
+		if t, err = ps.lx.next(); err != nil {
+			return nil, err
+		}
+		if t.kind == dotIdent {
+			if t, err = ps.lx.next(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if t.kind != dotPunct || t.text != "{" {
+		return nil, ps.lx.errorf("expected %q, found %q", "{", t.text)
+	}
+	edge := ps.edge
+	labels, err := ps.stmtList()
+	ps.edge = edge
+	return labels, err
+}
+
+// stmt parses a statement that starts with the ID t
+// and returns the labels of the vertices it names
+func (ps *dotParser) stmt(t dotToken) ([]Label, error) {
+	keyword := strings.ToLower(t.text)
+	n, err := ps.lx.next()
+	if err != nil {
+		return nil, err
+	}
+	if keyword == "graph" || keyword == "node" || keyword == "edge" {
+		if n.kind == dotPunct && n.text == "[" {
+			attrs, err := ps.attrList()
+			if err != nil {
+				return nil, err
+			}
+			if keyword == "edge" {
+				ps.edge = merge(ps.edge, attrs)
+			}
+			return nil, nil
+		}
+	}
+	if n.kind == dotPunct && n.text == "=" {
+		v, err := ps.lx.next()
+		if err != nil {
+			return nil, err
+		}
+		if v.kind != dotIdent {
+			return nil, ps.lx.errorf("expected an ID, found %q", v.text)
+		}
+		return nil, nil
+	}
+	if n, err = ps.port(n); err != nil {
+		return nil, err
+	}
+	return ps.edgeStmt([]Label{Label(t.text)}, n)
+}
+
+// edgeStmt parses the rest of a node or edge statement whose first
+// endpoint stands for the vertices labeled first, n being the token
+// after it. Each edge of a chain joins every vertex its tail stands
+// for to every vertex its head stands for
+func (ps *dotParser) edgeStmt(first []Label, n dotToken) ([]Label, error) {
+	ends := [][]Label{first}
+	var err error
+	for n.kind == dotPunct && (n.text == "->" || n.text == "--") {
+		if n.text != ps.op {
+			return nil, ps.lx.errorf("%q in a graph using %q", n.text, ps.op)
+		}
+		v, err := ps.lx.next()
+		if err != nil {
+			return nil, err
+		}
+		var ids []Label
+		switch {
+		case isSubgraph(v):
+			if ids, err = ps.subgraph(v); err != nil {
+				return nil, err
+			}
+			if n, err = ps.lx.next(); err != nil {
+				return nil, err
+			}
+		case v.kind == dotIdent:
+			ids = []Label{Label(v.text)}
+			if n, err = ps.lx.next(); err != nil {
+				return nil, err
+			}
+			if n, err = ps.port(n); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, ps.lx.errorf("expected an ID or subgraph, found %q", v.text)
+		}
+		ends = append(ends, ids)
+	}
+	var attrs map[string]string
+	if n.kind == dotPunct && n.text == "[" {
+		if attrs, err = ps.attrList(); err != nil {
+			return nil, err
+		}
+	} else {
+		ps.lx.peek = &n
+	}
+	var labels []Label
+	for _, ids := range ends {
+		for _, id := range ids {
+			ps.G.AddVertex(id)
+		}
+		labels = append(labels, ids...)
+	}
+	if len(ends) == 1 {
+		return labels, nil
+	}
+	attrs = merge(ps.edge, attrs)
+	w := 1
+	if s, ok := attrs["weight"]; ok {
+		if w, err = strconv.Atoi(s); err != nil {
+			return nil, ps.lx.errorf("weight %q is not an integer", s)
+		}
+	} else if l, err := strconv.Atoi(attrs["label"]); err == nil {
+		w = l
+	}
+	for i := 1; i < len(ends); i++ {
+		for _, u := range ends[i-1] {
+			for _, v := range ends[i] {
+				if err := ps.G.AddEdge(u, v, w); err == ErrEdgeExists {
+					ps.G.SetWeight(u, v, w)
+				}
+			}
+		}
+	}
+	return labels, nil
+}
+
+// port skips a port such as :n or :p1:sw following a node ID
+// and returns the token after it
+func (ps *dotParser) port(n dotToken) (dotToken, error) {
+	var err error
+	for n.kind == dotPunct && n.text == ":" {
+		if n, err = ps.lx.next(); err != nil {
+			return n, err
+		}
+		if n.kind != dotIdent {
+			return n, ps.lx.errorf("expected a port, found %q", n.text)
+		}
+		if n, err = ps.lx.next(); err != nil {
+			return n, err
+		}
+	}
+	return n, nil
+}
+
+// attrList parses attributes up to the closing bracket, including
+// any further bracketed lists that follow it
+func (ps *dotParser) attrList() (map[string]string, error) {
+	attrs := map[string]string{}
+	for {
+		t, err := ps.lx.next()
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case t.kind == dotPunct && t.text == "]":
+			n, err := ps.lx.next()
+			if err != nil {
+				return nil, err
+			}
+			if n.kind != dotPunct || n.text != "[" {
+				ps.lx.peek = &n
+				return attrs, nil
+			}
+		case t.kind == dotPunct && (t.text == ";" || t.text == ","):
+		case t.kind == dotIdent:
+			if err := ps.expect("="); err != nil {
+				return nil, err
+			}
+			v, err := ps.lx.next()
+			if err != nil {
+				return nil, err
+			}
+			if v.kind != dotIdent {
+				return nil, ps.lx.errorf("expected a value for %s, found %q", t.text, v.text)
+			}
+			attrs[strings.ToLower(t.text)] = v.text
+		default:
+			return nil, ps.lx.errorf("unexpected %q", t.text)
+		}
+	}
+}
+
+// merge returns the attributes of a overridden by those of b,
+// leaving both unchanged
+func merge(a, b map[string]string) map[string]string {
+	m := make(map[string]string, len(a)+len(b))
+	for k, v := range a {
+		m[k] = v
+	}
+	for k, v := range b {
+		m[k] = v
+	}
+	return m
+}
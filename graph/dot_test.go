@@ -0,0 +1,161 @@
+package graph
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// sameGraph checks that G and H have the same vertices and weighted edges
+func sameGraph(t *testing.T, G, H *Graph) {
+	if G.Undirected != H.Undirected {
+		t.Errorf("expected undirected to be %v, got %v", G.Undirected, H.Undirected)
+	}
+	if G.VNum != H.VNum || G.ENum != H.ENum {
+		t.Fatalf("expected %d vertices and %d edges, got %d and %d", G.VNum, G.ENum, H.VNum, H.ENum)
+	}
+	for l := range G.V {
+		if _, ok := H.V[l]; !ok {
+			t.Errorf("expected vertex %s", l)
+		}
+	}
+	for e, w := range G.E {
+		if hw, ok := H.Weight(e.U.Label, e.V.Label); !ok || hw != w {
+			t.Errorf("expected edge %s of weight %d, got %d", e, w, hw)
+		}
+	}
+}
+
+func TestDOTRoundTrip(t *testing.T) {
+	for _, G := range []*Graph{
+		BuildWeightedGraph(clrsNegative),
+		BuildUndirectedWeightedGraph(clrsMST),
+		BuildGraph([][2]string{{`say "hi"`, "two words"}, {"lonely", ""}}),
+		BuildGraph([][2]string{{`a\`, `b\"c`}, {`C:\dir\`, `\\`}}),
+	} {
+		var b bytes.Buffer
+		if err := WriteDOT(&b, G, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		H, err := ParseDOT(&b)
+		if err != nil {
+			t.Fatalf("unexpected error: %v\n%s", err, b.String())
+		}
+		sameGraph(t, G, H)
+	}
+}
+
+func TestParseDOT(t *testing.T) {
+	src := `/* service calls */
+strict digraph "calls" {
+	graph [rankdir=LR]; node [shape=box]
+	rankdir = TB
+	# a chain of edges shares its attributes
+	api -> auth -> db [weight=3, color=blue]
+	api:e -> cache [label="2"][style=dashed]
+	cache -> db [label=fast] // not a weight
+	subgraph cluster_0 { worker; worker -> db }
+	"api" -> "auth" [weight=-1];
+}`
+	G, err := ParseDOT(strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	sameGraph(t, BuildWeightedGraph([]weightedPair{
+		{Pair: [2]string{"api", "auth"}, Weight: -1},
+		{Pair: [2]string{"auth", "db"}, Weight: 3},
+		{Pair: [2]string{"api", "cache"}, Weight: 2},
+		{Pair: [2]string{"cache", "db"}, Weight: 1},
+		{Pair: [2]string{"worker", "db"}, Weight: 1},
+	}), G)
+	for _, src := range []string{
+		`digraph { a -- b }`,
+		`graph { a -> b }`,
+		`digraph { a -> b [weight=heavy] }`,
+		`digraph { a -> b`,
+		`tree { a }`,
+	} {
+		if _, err := ParseDOT(strings.NewReader(src)); err == nil {
+			t.Errorf("expected an error parsing %s", src)
+		}
+	}
+}
+
+func TestParseDOTSubgraphEdges(t *testing.T) {
+	src := `digraph {
+	node [label=<<b>service</b>>]
+	gw -> {api web} -> subgraph s { db; cache } [weight=2]
+	{a b} -> c
+	<x> -> "y"
+}`
+	G, err := ParseDOT(strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	sameGraph(t, BuildWeightedGraph([]weightedPair{
+		{Pair: [2]string{"gw", "api"}, Weight: 2},
+		{Pair: [2]string{"gw", "web"}, Weight: 2},
+		{Pair: [2]string{"api", "db"}, Weight: 2},
+		{Pair: [2]string{"api", "cache"}, Weight: 2},
+		{Pair: [2]string{"web", "db"}, Weight: 2},
+		{Pair: [2]string{"web", "cache"}, Weight: 2},
+		{Pair: [2]string{"a", "c"}, Weight: 1},
+		{Pair: [2]string{"b", "c"}, Weight: 1},
+		{Pair: [2]string{"x", "y"}, Weight: 1},
+	}), G)
+	if _, err := ParseDOT(strings.NewReader(`digraph { a [label=<b>] -> c }`)); err == nil {
+		t.Errorf("expected an error")
+	}
+	if _, err := ParseDOT(strings.NewReader(`digraph { a [label=<<b>] }`)); err == nil {
+		t.Errorf("expected an error parsing an unterminated HTML string")
+	}
+}
+
+func TestParseDOTEdgeDefaults(t *testing.T) {
+	src := `digraph {
+	a -> b
+	edge [weight=5]
+	c -> d
+	subgraph { edge [label=3, weight=7]; e -> f; g -> h [weight=2] }
+	i -> j
+	edge [color=red]
+	k -> l [label=4]
+}`
+	G, err := ParseDOT(strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	sameGraph(t, BuildWeightedGraph([]weightedPair{
+		{Pair: [2]string{"a", "b"}, Weight: 1},
+		{Pair: [2]string{"c", "d"}, Weight: 5},
+		{Pair: [2]string{"e", "f"}, Weight: 7},
+		{Pair: [2]string{"g", "h"}, Weight: 2},
+		{Pair: [2]string{"i", "j"}, Weight: 5},
+		{Pair: [2]string{"k", "l"}, Weight: 5},
+	}), G)
+}
+
+func TestWriteDOTOptions(t *testing.T) {
+	G := BuildGraph(clrsSCC)
+	var b bytes.Buffer
+	err := WriteDOT(&b, G, &DOTOptions{
+		Name:   "scc",
+		Path:   []Label{"a", "b", "c"},
+		Groups: ComponentGroups(SCC(G)),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := b.String()
+	for _, s := range []string{
+		`digraph "scc" {`,
+		`"a" [style=filled, fillcolor=lightblue, color=red, penwidth=2];`,
+		`"h" [style=filled, fillcolor=khaki];`,
+		`"b" -> "c" [color=red, penwidth=2];`,
+		`"b" -> "e";`,
+	} {
+		if !strings.Contains(out, s) {
+			t.Errorf("expected output to contain %s, got:\n%s", s, out)
+		}
+	}
+}
@@ -0,0 +1,212 @@
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrDegreeImbalance occurs when a vertex of a directed graph has
+	// an in-degree different from its out-degree, or a vertex of an
+	// undirected graph an odd degree, where an Euler tour forbids it
+	ErrDegreeImbalance = errors.New("vertex degrees are unbalanced")
+	// ErrEdgesDisconnected occurs when the edges of a graph
+	// are spread over more than one connected component
+	ErrEdgesDisconnected = errors.New("edges are not all connected")
+)
+
+// EulerError explains why a graph has no Eulerian path or circuit,
+// Err is ErrDegreeImbalance, in which case Labels holds the vertices
+// whose degrees are at fault, or ErrEdgesDisconnected, in which case
+// Labels holds the smallest label of each component with edges
+type EulerError struct {
+	Err    error
+	Labels []Label
+}
+
+// Error implements the error interface
+func (e *EulerError) Error() string {
+	l := make([]string, len(e.Labels))
+	for i, j := range e.Labels {
+		l[i] = string(j)
+	}
+	return fmt.Sprintf("%v: %s", e.Err, strings.Join(l, ", "))
+}
+
+// Unwrap returns the reason the graph failed the check
+func (e *EulerError) Unwrap() error {
+	return e.Err
+}
+
+// imbalance returns out-degree less in-degree of each vertex of a
+// directed graph, or the degree of each vertex of an undirected one
+// where a self-loop counts twice
+func (G *Graph) imbalance() map[Label]int {
+	d := make(map[Label]int, len(G.V))
+	for e := range G.E {
+		if G.Undirected {
+			d[e.U.Label]++
+			d[e.V.Label]++
+		} else {
+			d[e.U.Label]++
+			d[e.V.Label]--
+		}
+	}
+	return d
+}
+
+// edgesConnected checks that all the edges of G lie in a
+// single connected component, regardless of their direction
+func (G *Graph) edgesConnected() error {
+	var roots []Label
+	for _, c := range ConnectedComponents(G) {
+		for _, l := range c {
+			if len(G.V[l].Adj) > 0 {
+				roots = append(roots, c[0])
+				break
+			}
+		}
+	}
+	if len(roots) > 1 {
+		return &EulerError{Err: ErrEdgesDisconnected, Labels: roots}
+	}
+	return nil
+}
+
+// eulerStart checks the degrees of G, allowing the vertices an
+// Eulerian path may start and end at when path is true, and returns
+// the vertex a tour should start from: the smallest label with an
+// edge for a circuit, otherwise the vertex with one more edge out
+// than in, or the smaller of the two vertices of odd degree
+func (G *Graph) eulerStart(path bool) (Label, error) {
+	d := G.imbalance()
+	var faults, starts []Label
+	for _, l := range G.labels() {
+		switch x := d[l]; {
+		case G.Undirected && x%2 != 0:
+			faults = append(faults, l)
+			starts = append(starts, l)
+		case !G.Undirected && x != 0:
+			faults = append(faults, l)
+			if x == 1 {
+				starts = append(starts, l)
+			}
+		}
+	}
+	balanced := len(faults) == 0
+	if path && len(faults) == 2 && len(starts) > 0 {
+		balanced = G.Undirected || len(starts) == 1
+	}
+	if !balanced {
+		return "", &EulerError{Err: ErrDegreeImbalance, Labels: faults}
+	}
+	if err := G.edgesConnected(); err != nil {
+		return "", err
+	}
+	if len(starts) > 0 {
+		return starts[0], nil
+	}
+	for _, l := range G.labels() {
+		if len(G.V[l].Adj) > 0 {
+			return l, nil
+		}
+	}
+	if G.VNum > 0 {
+		return G.labels()[0], nil
+	}
+	return "", nil
+}
+
+// CheckEulerianCircuit checks whether G has an Eulerian circuit, a
+// closed walk through every edge exactly once, which it has if and
+// only if every vertex of a directed graph has as many edges in as
+// out, or every vertex of an undirected graph has an even degree,
+// and all its edges lie in one connected component
+// It returns an *EulerError explaining the failure otherwise
+// This is achieved in an order 0(V + E)-time
+func CheckEulerianCircuit(G *Graph) error {
+	_, err := G.eulerStart(false)
+	return err
+}
+
+// CheckEulerianPath checks whether G has an Eulerian path, a walk
+// through every edge exactly once, which it has if it satisfies
+// CheckEulerianCircuit except that the walk may start at a vertex
+// of a directed graph with one more edge out than in and end at
+// one with one more in than out, or start and end at the only two
+// vertices of odd degree of an undirected graph
+// It returns an *EulerError explaining the failure otherwise
+// This is achieved in an order 0(V + E)-time
+func CheckEulerianPath(G *Graph) error {
+	_, err := G.eulerStart(true)
+	return err
+}
+
+// EulerianCircuit returns an Eulerian circuit of G, starting and
+// ending at its smallest label with an edge, built by Hierholzer's
+// algorithm. It returns an *EulerError if G has no such circuit
+// This is achieved in an order 0(V + E)-time
+func EulerianCircuit(G *Graph) (*Path, error) {
+	s, err := G.eulerStart(false)
+	if err != nil {
+		return nil, err
+	}
+	return G.hierholzer(s), nil
+}
+
+// EulerianPath returns an Eulerian path of G built by Hierholzer's
+// algorithm, which is a circuit when G has one, and otherwise starts
+// at the vertex that has to start it. It returns an *EulerError if G
+// has no such path
+// This is achieved in an order 0(V + E)-time
+func EulerianPath(G *Graph) (*Path, error) {
+	s, err := G.eulerStart(true)
+	if err != nil {
+		return nil, err
+	}
+	return G.hierholzer(s), nil
+}
+
+// hierholzer walks unused edges from s until it gets stuck, which
+// can only happen at the end of the tour, and backtracks along the
+// walk splicing in a further closed walk from each vertex that still
+// has unused edges, the vertices being added to the tour as they are
+// backtracked from, so that the tour comes out in reverse
+// Each edge is used once, the next unused edge out of each vertex
+// being found by moving a position in its adjacency list forward
+func (G *Graph) hierholzer(s Label) *Path {
+	p := &Path{}
+	if s == "" {
+		return p
+	}
+	next := make(map[Label]int, len(G.V))
+	used := make(map[Edge]bool, len(G.E))
+	stack := []Label{s}
+	for len(stack) > 0 {
+		u := stack[len(stack)-1]
+		adj := G.V[u].Adj
+		advanced := false
+		for next[u] < len(adj) && !advanced {
+			j := adj[next[u]]
+			next[u]++
+			if e := G.key(NewEdge(G.V[u], G.V[j])); !used[e] {
+				used[e] = true
+				stack = append(stack, j)
+				advanced = true
+			}
+		}
+		if !advanced {
+			p.Labels = append(p.Labels, u)
+			stack = stack[:len(stack)-1]
+		}
+	}
+	for i, j := 0, len(p.Labels)-1; i < j; i, j = i+1, j-1 {
+		p.Labels[i], p.Labels[j] = p.Labels[j], p.Labels[i]
+	}
+	for i := 1; i < len(p.Labels); i++ {
+		p.Weight += G.weight(NewEdge(G.V[p.Labels[i-1]], G.V[p.Labels[i]]))
+	}
+	p.Hops = len(p.Labels) - 1
+	return p
+}
@@ -0,0 +1,106 @@
+package graph
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// eulerTour checks that p uses every edge of G exactly once
+func eulerTour(t *testing.T, G *Graph, p *Path) {
+	t.Helper()
+	if p.Hops != G.ENum {
+		t.Fatalf("expected %d edges, got %d: %s", G.ENum, p.Hops, p)
+	}
+	used := make(map[Edge]bool)
+	for i := 1; i < len(p.Labels); i++ {
+		e, ok := G.edge(p.Labels[i-1], p.Labels[i])
+		if !ok || used[e] {
+			t.Fatalf("expected (%s, %s) to be an unused edge in %s", p.Labels[i-1], p.Labels[i], p)
+		}
+		used[e] = true
+	}
+}
+
+func TestEulerianCircuit(t *testing.T) {
+	// two triangles sharing a vertex, and a self-loop
+	pairs := [][2]string{
+		{"a", "b"}, {"b", "c"}, {"c", "a"},
+		{"c", "d"}, {"d", "e"}, {"e", "c"},
+		{"e", "e"},
+	}
+	for name, G := range map[string]*Graph{
+		"directed":   BuildGraph(pairs),
+		"undirected": BuildUndirectedGraph(pairs),
+	} {
+		if err := CheckEulerianCircuit(G); err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+		p, err := EulerianCircuit(G)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+		eulerTour(t, G, p)
+		if p.Labels[0] != "a" || p.Labels[p.Hops] != "a" {
+			t.Errorf("%s: expected a circuit from a, got %s", name, p)
+		}
+	}
+}
+
+func TestEulerianPath(t *testing.T) {
+	// the bridges of Königsberg with one bridge
+	// fewer, leaving two land masses of odd degree
+	G := BuildUndirectedGraph([][2]string{
+		{"n", "i"}, {"n", "i2"}, {"n", "e"},
+		{"s", "i"}, {"s", "i2"}, {"s", "e"},
+		{"i", "e"},
+	})
+	G.RemoveVertex("i2")
+	G.AddVertex("i2")
+	err := CheckEulerianCircuit(G)
+	var ee *EulerError
+	if !errors.As(err, &ee) || ee.Err != ErrDegreeImbalance || !reflect.DeepEqual(ee.Labels, []Label{"e", "i"}) {
+		t.Errorf("expected e and i to have odd degrees, got %v", err)
+	}
+	p, err := EulerianPath(G)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	eulerTour(t, G, p)
+	if p.Labels[0] != "e" || p.Labels[p.Hops] != "i" {
+		t.Errorf("expected a path from e to i, got %s", p)
+	}
+
+	D := BuildGraph([][2]string{{"a", "b"}, {"b", "c"}, {"c", "a"}, {"a", "d"}})
+	p, err = EulerianPath(D)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	eulerTour(t, D, p)
+	if p.String() != "a -> b -> c -> a -> d" {
+		t.Errorf("expected a -> b -> c -> a -> d, got %s", p)
+	}
+	D.AddEdge("b", "d", 1)
+	if err := CheckEulerianPath(D); !errors.Is(err, ErrDegreeImbalance) {
+		t.Errorf("expected %v, got %v", ErrDegreeImbalance, err)
+	}
+}
+
+func TestEulerianDisconnected(t *testing.T) {
+	G := BuildUndirectedGraph([][2]string{
+		{"a", "b"}, {"b", "c"}, {"c", "a"},
+		{"x", "y"}, {"y", "z"}, {"z", "x"},
+		{"lone", ""},
+	})
+	err := CheckEulerianCircuit(G)
+	var ee *EulerError
+	if !errors.As(err, &ee) || ee.Err != ErrEdgesDisconnected || !reflect.DeepEqual(ee.Labels, []Label{"a", "x"}) {
+		t.Errorf("expected the edges of a and x to be disconnected, got %v", err)
+	}
+	if err.Error() != "edges are not all connected: a, x" {
+		t.Errorf("unexpected message %q", err)
+	}
+	if p, err := EulerianCircuit(BuildGraph([][2]string{{"lone", ""}})); err != nil || p.Hops != 0 {
+		t.Errorf("expected an empty circuit, got %v: %v", p, err)
+	}
+}
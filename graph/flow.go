@@ -0,0 +1,245 @@
+package graph
+
+import "errors"
+
+var (
+	// ErrSourceIsSink occurs when the source and the sink
+	// of a flow network are the same vertex
+	ErrSourceIsSink = errors.New("source and sink are the same vertex")
+	// ErrNegativeCapacity occurs when an edge of a flow
+	// network is given a negative capacity
+	ErrNegativeCapacity = errors.New("edge has a negative capacity")
+)
+
+// Flow is a maximum flow in a flow network G = (V, E) where the
+// weight of each edge (u, v) ∈ E is its capacity c(u, v) >= 0
+// Value is |f| - the total net flow out of the source,
+// F gives the flow f(u, v) on each edge of G and Cut holds the
+// edges of a minimum s-t cut (S, T), which are all saturated and
+// whose capacities add up to Value
+// An edge {u, v} of an undirected graph may carry flow either way,
+// up to its capacity, F holds the net flow from u to v which is
+// negative when the flow goes from v to u
+type Flow struct {
+	Value int
+	F     map[Edge]int
+	Cut   []Edge
+}
+
+// residual is the residual network Gf of a flow network G
+// flow is kept skew symmetric - f(u, v) = -f(v, u) - so that the
+// residual capacity of (u, v) is cf(u, v) = c(u, v) - f(u, v)
+type residual struct {
+	G   *Graph
+	adj map[Label][]Label
+	c   map[[2]Label]int
+	f   map[[2]Label]int
+}
+
+// newResidual returns the residual network of G with no flow,
+// it fails if any edge has a negative capacity
+func newResidual(G *Graph) (*residual, error) {
+	r := &residual{
+		G:   G,
+		adj: make(map[Label][]Label, len(G.V)),
+		c:   make(map[[2]Label]int, len(G.E)),
+		f:   make(map[[2]Label]int, 2*len(G.E)),
+	}
+	for e, w := range G.E {
+		if w < 0 {
+			return nil, ErrNegativeCapacity
+		}
+		u, v := e.U.Label, e.V.Label
+		if u == v {
+			continue
+		}
+		r.c[[2]Label{u, v}] = w
+		if G.Undirected {
+			r.c[[2]Label{v, u}] = w
+		}
+		r.adj[u] = append(r.adj[u], v)
+		if _, ok := G.E[NewEdge(e.V, e.U)]; !ok || G.Undirected {
+			r.adj[v] = append(r.adj[v], u)
+		}
+	}
+	for _, l := range r.adj {
+		sortLabels(l)
+	}
+	return r, nil
+}
+
+// cf returns the residual capacity of (u, v)
+func (r *residual) cf(u, v Label) int {
+	return r.c[[2]Label{u, v}] - r.f[[2]Label{u, v}]
+}
+
+// push sends d units of flow along (u, v)
+func (r *residual) push(u, v Label, d int) {
+	r.f[[2]Label{u, v}] += d
+	r.f[[2]Label{v, u}] -= d
+}
+
+// augmentingPath returns the predecessor of each vertex on a shortest
+// path from s to t through edges with positive residual capacity,
+// found by BFS, and whether t is reachable from s at all
+func (r *residual) augmentingPath(s, t Label) (map[Label]Label, bool) {
+	pred := map[Label]Label{s: s}
+	Q := []Label{s}
+	for len(Q) > 0 {
+		u := Q[0]
+		Q = Q[1:]
+		for _, v := range r.adj[u] {
+			if _, ok := pred[v]; !ok && r.cf(u, v) > 0 {
+				pred[v] = u
+				if v == t {
+					return pred, true
+				}
+				Q = append(Q, v)
+			}
+		}
+	}
+	return nil, false
+}
+
+// levels returns the breadth-first distance of every vertex
+// reachable from s through edges with positive residual capacity
+func (r *residual) levels(s Label) map[Label]int {
+	level := map[Label]int{s: 0}
+	Q := []Label{s}
+	for len(Q) > 0 {
+		u := Q[0]
+		Q = Q[1:]
+		for _, v := range r.adj[u] {
+			if _, ok := level[v]; !ok && r.cf(u, v) > 0 {
+				level[v] = level[u] + 1
+				Q = append(Q, v)
+			}
+		}
+	}
+	return level
+}
+
+// flow returns the maximum flow f once no augmenting path is left,
+// the vertices reachable from s in Gf form the S side of a minimum cut
+func (r *residual) flow(s Label) *Flow {
+	S := r.levels(s)
+	fl := &Flow{F: make(map[Edge]int, len(r.G.E))}
+	for e := range r.G.E {
+		u, v := e.U.Label, e.V.Label
+		if u == v {
+			fl.F[e] = 0
+			continue
+		}
+		if f := r.f[[2]Label{u, v}]; f > 0 || r.G.Undirected {
+			fl.F[e] = f
+		} else {
+			fl.F[e] = 0
+		}
+		_, uInS := S[u]
+		_, vInS := S[v]
+		if uInS && !vInS || r.G.Undirected && vInS && !uInS {
+			fl.Cut = append(fl.Cut, e)
+		}
+	}
+	for _, v := range r.adj[s] {
+		fl.Value += r.f[[2]Label{s, v}]
+	}
+	sortEdges(fl.Cut)
+	return fl
+}
+
+// checkFlowNetwork validates the source and sink of a flow network
+func checkFlowNetwork(G *Graph, s, t Label) error {
+	if _, ok := G.V[s]; !ok {
+		return ErrVertexNotFound
+	}
+	if _, ok := G.V[t]; !ok {
+		return ErrVertexNotFound
+	}
+	if s == t {
+		return ErrSourceIsSink
+	}
+	return nil
+}
+
+// EdmondsKarp computes a maximum flow from s to t in G using the
+// Ford-Fulkerson method, where each augmenting path is found as a
+// shortest path from s to t in the residual network Gf by BFS.
+// The flow is increased along the path by its residual capacity,
+// the least residual capacity of any of its edges, until no
+// augmenting path remains
+// This is achieved in an order 0(VE²)-time
+func EdmondsKarp(G *Graph, s, t Label) (*Flow, error) {
+	if err := checkFlowNetwork(G, s, t); err != nil {
+		return nil, err
+	}
+	r, err := newResidual(G)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		pred, ok := r.augmentingPath(s, t)
+		if !ok {
+			break
+		}
+		cf := infinity
+		for v := t; v != s; v = pred[v] {
+			if c := r.cf(pred[v], v); c < cf {
+				cf = c
+			}
+		}
+		for v := t; v != s; v = pred[v] {
+			r.push(pred[v], v, cf)
+		}
+	}
+	return r.flow(s), nil
+}
+
+// Dinic computes a maximum flow from s to t in G in phases. Each
+// phase labels the vertices with their BFS level in the residual
+// network and then finds a blocking flow in the level graph, which
+// holds only the residual edges (u, v) where level(v) = level(u) + 1,
+// by repeated depth-first searches that never revisit a dead edge
+// There are at most |V| - 1 phases, which makes it run in an order
+// 0(V²E)-time and 0(E√V)-time on unit capacity networks
+func Dinic(G *Graph, s, t Label) (*Flow, error) {
+	if err := checkFlowNetwork(G, s, t); err != nil {
+		return nil, err
+	}
+	r, err := newResidual(G)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		level := r.levels(s)
+		if _, ok := level[t]; !ok {
+			break
+		}
+		next := make(map[Label]int, len(level))
+		var augment func(Label, int) int
+		augment = func(u Label, limit int) int {
+			if u == t {
+				return limit
+			}
+			for ; next[u] < len(r.adj[u]); next[u]++ {
+				v := r.adj[u][next[u]]
+				lv, ok := level[v]
+				if !ok || lv != level[u]+1 || r.cf(u, v) <= 0 {
+					continue
+				}
+				d := limit
+				if c := r.cf(u, v); c < d {
+					d = c
+				}
+				if pushed := augment(v, d); pushed > 0 {
+					r.push(u, v, pushed)
+					return pushed
+				}
+			}
+			return 0
+		}
+		for augment(s, infinity) > 0 {
+		}
+	}
+	return r.flow(s), nil
+}
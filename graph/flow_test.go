@@ -0,0 +1,107 @@
+package graph
+
+import "testing"
+
+// clrsFlow is the flow network of figure 26.1 in CLRS
+var clrsFlow = []weightedPair{
+	{Pair: [2]string{"s", "v1"}, Weight: 16},
+	{Pair: [2]string{"s", "v2"}, Weight: 13},
+	{Pair: [2]string{"v1", "v3"}, Weight: 12},
+	{Pair: [2]string{"v2", "v1"}, Weight: 4},
+	{Pair: [2]string{"v2", "v4"}, Weight: 14},
+	{Pair: [2]string{"v3", "v2"}, Weight: 9},
+	{Pair: [2]string{"v3", "t"}, Weight: 20},
+	{Pair: [2]string{"v4", "v3"}, Weight: 7},
+	{Pair: [2]string{"v4", "t"}, Weight: 4},
+}
+
+func TestMaxFlow(t *testing.T) {
+	for name, maxFlow := range map[string]func(*Graph, Label, Label) (*Flow, error){
+		"edmonds-karp": EdmondsKarp,
+		"dinic":        Dinic,
+	} {
+		G := BuildWeightedGraph(clrsFlow)
+		fl, err := maxFlow(G, "s", "t")
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+		if fl.Value != 23 {
+			t.Errorf("%s: expected flow value %d, got %d", name, 23, fl.Value)
+		}
+		// flow conservation and capacity constraints
+		net := map[Label]int{}
+		for e, f := range fl.F {
+			if f < 0 || f > G.E[e] {
+				t.Errorf("%s: flow %d on %s violates its capacity %d", name, f, e, G.E[e])
+			}
+			net[e.U.Label] -= f
+			net[e.V.Label] += f
+		}
+		for l, n := range net {
+			if l != "s" && l != "t" && n != 0 {
+				t.Errorf("%s: expected flow into %s to be conserved, got %d", name, l, n)
+			}
+		}
+		var c int
+		for _, e := range fl.Cut {
+			if fl.F[e] != G.E[e] {
+				t.Errorf("%s: expected cut edge %s to be saturated", name, e)
+			}
+			c += G.E[e]
+		}
+		if c != fl.Value {
+			t.Errorf("%s: expected cut capacity %d to equal flow value %d", name, c, fl.Value)
+		}
+		t.Logf("%s: cut %v", name, fl.Cut)
+	}
+}
+
+func TestMaxFlowErrors(t *testing.T) {
+	G := BuildWeightedGraph(clrsFlow)
+	if _, err := Dinic(G, "s", "s"); err != ErrSourceIsSink {
+		t.Errorf("expected %v, got %v", ErrSourceIsSink, err)
+	}
+	if _, err := EdmondsKarp(G, "s", "q"); err != ErrVertexNotFound {
+		t.Errorf("expected %v, got %v", ErrVertexNotFound, err)
+	}
+	G = BuildWeightedGraph([]weightedPair{{Pair: [2]string{"s", "t"}, Weight: -1}})
+	if _, err := EdmondsKarp(G, "s", "t"); err != ErrNegativeCapacity {
+		t.Errorf("expected %v, got %v", ErrNegativeCapacity, err)
+	}
+}
+
+// flowGrid is an undirected rows x cols grid of unit capacities with
+// a source s joined to every cell of the first column and a sink t
+// joined to every cell of the last, so that rows units of flow cross it
+func flowGrid(rows, cols int) *Graph {
+	G := BuildGrid(rows, cols, nil)
+	G.AddVertex("s")
+	G.AddVertex("t")
+	for r := 0; r < rows; r++ {
+		G.AddEdge("s", GridLabel(r, 0), 1)
+		G.AddEdge(GridLabel(r, cols-1), "t", 1)
+	}
+	return G
+}
+
+func BenchmarkMaxFlow_EdmondsKarp(b *testing.B) {
+	// EdmondsKarp augments along one shortest path
+	// per search of the residual network
+	G := flowGrid(30, 30)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		EdmondsKarp(G, "s", "t")
+	}
+}
+
+func BenchmarkMaxFlow_Dinic(b *testing.B) {
+	// Dinic augments along every shortest path of
+	// a level graph per search of the residual network
+	G := flowGrid(30, 30)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Dinic(G, "s", "t")
+	}
+}
@@ -1,16 +1,17 @@
 /*
 Package graph implements basic operations on the graph
 data structure including topological sort, depth first search
-and breadth first search. It also provides an implementation for finding
+and breadth first search. It also provides implementations for finding
 strongly connected components of a graph using the graph's
-transpose.
+transpose, or Tarjan's single pass algorithm.
 */
 package graph
 
 import (
 	"errors"
 	"fmt"
-	"io"
+	"sort"
+	"strconv"
 
 	"github.com/willpoint/algor/list"
 )
@@ -23,43 +24,63 @@ const (
 	black              // all adjacent vertices have been visited
 )
 
+// EdgeKind is the kind of an edge (u, v) in a depth-first forest
+type EdgeKind int
+
+const (
+	// Tree edges lead to the vertex v they discover
+	Tree EdgeKind = iota
+	// Back edges lead to an ancestor v of u
+	Back
+	// Forward edges lead to a descendant v of u
+	// that is not a child of u in the forest
+	Forward
+	// Cross edges lead to a vertex v that is
+	// neither an ancestor nor a descendant of u
+	Cross
+)
+
+// String implements the Stringer interface
+func (k EdgeKind) String() string {
+	names := [...]string{"tree", "back", "forward", "cross"}
+	if k < 0 || int(k) >= len(names) {
+		return fmt.Sprintf("EdgeKind(%d)", int(k))
+	}
+	return names[k]
+}
+
 var (
 	// ErrVertexExists ...
 	ErrVertexExists = errors.New("vertex already exists")
+	// ErrVertexNotFound is returned when a label does not
+	// identify any vertex in the graph
+	ErrVertexNotFound = errors.New("vertex not found")
 )
 
 // Label identifying a vertexs
 type Label string
 
 // Vertex is a vertex of graph G = (V, E)
+// It holds no traversal state, each search returns the attributes
+// it computes for the vertices (distances, predecessors, timestamps)
+// in a result keyed by Label, so searching a Graph never modifies
+// it and a Graph can be searched by many goroutines at once as long
+// as none of them mutates it
 type Vertex struct {
-	Adj         []Label
-	Label       Label
-	Predecessor *Vertex
-	Distance    int
-	dstamp      int
-	fstamp      int
-	color       color
+	Adj   []Label
+	Label Label
 }
 
 // NewVertex creates a new Vertex
 func NewVertex(l Label) *Vertex {
 	return &Vertex{
 		Label: l,
-		color: white,
 	}
 }
 
-// String implements the Stringer interface // pi - `u03c0`
+// String implements the Stringer interface
 func (v *Vertex) String() string {
-	var p Label = "NIL"
-	if v.Predecessor != nil {
-		p = v.Predecessor.Label
-	}
-	return fmt.Sprintf(
-		"%s[c: %d, D: %d, π: %s, adj: %d, dstamp: %d, fstamp: %d]",
-		v.Label, v.color, v.Distance, p, len(v.Adj), v.dstamp, v.fstamp,
-	)
+	return fmt.Sprintf("%s[adj: %v]", v.Label, v.Adj)
 }
 
 // Edge E is a pair of vertices (u, v) ∈ E in Graph G = (V, E)
@@ -96,11 +117,15 @@ func NewWeightedEdge(u, v *Vertex, w int) (Edge, int) {
 // weights are unit weights (1) for unweighted edges
 // and take on real values 0 <= w <= maxInt for positive weights
 // and minInt <= w <= maxInt for negative weight edges
+// An undirected graph holds each edge {u, v} once in E, ordered
+// such that u.Label <= v.Label, while both u and v are in the
+// adjacency list of the other
 type Graph struct {
 	V map[Label]*Vertex
 	E map[Edge]int
 
 	VNum, ENum int
+	Undirected bool
 }
 
 // NewGraph returns a references to a new Graph G
@@ -112,7 +137,6 @@ func NewGraph() *Graph {
 }
 
 // String implements the Stringer interface for Graph G
-// with the assumption that a BFS, or DFS is already computed
 func (G *Graph) String() string {
 	var s string
 	s += fmt.Sprintln("---Vertex Set---")
@@ -130,158 +154,227 @@ func (G *Graph) String() string {
 // BuildGraph initializes a  Graph G = (V, E) with
 // a slice of slice of strings
 // for each slice the first element represents u and the
-// second represents v
+// second represents v, a pair repeated is added only once
 func BuildGraph(pairs [][2]string) *Graph {
-	G := NewGraph()
+	return addPairs(NewGraph(), pairs)
+}
+
+// addPairs adds the vertices and edges given by pairs to G
+func addPairs(G *Graph, pairs [][2]string) *Graph {
 	for _, p := range pairs {
 		lu, lv := Label(p[0]), Label(p[1])
+		G.AddVertex(lu)
 		// if lv(2nd element of the pair) has a zero
 		// value then lv points to no other vertex
 		// we add to Vertex Set and continue
 		if lv == Label("") {
-			G.V[lu] = NewVertex(lu)
-			G.VNum++
 			continue
 		}
-		if G.V[lu] == nil {
-			u := NewVertex(lu)
-			G.VNum++
-			G.V[lu] = u
-		}
-		G.V[lu].Adj = append(G.V[lu].Adj, Label(lv))
-		if G.V[lv] == nil {
-			v := NewVertex(lv)
-			G.VNum++
-			G.V[lv] = v
-			edge := NewEdge(G.V[lu], v)
-			G.E[edge] = 1
-		} else {
-			edge := NewEdge(G.V[lu], G.V[lv])
-			G.E[edge] = 1
-		}
-		G.ENum++
+		G.AddVertex(lv)
+		G.AddEdge(lu, lv, 1)
 	}
 	return G
 }
 
 // BuildWeightedGraph is initializes a  Graph G = (V, E)
-// with weighted edges
+// with weighted edges, a pair repeated takes the last weight given
 func BuildWeightedGraph(pairs []struct {
 	Pair   [2]string `json:"pair"`
 	Weight int       `json:"weight"`
 }) *Graph {
-	G := NewGraph()
+	return addWeightedPairs(NewGraph(), pairs)
+}
+
+// addWeightedPairs adds the vertices and weighted edges given by pairs to G
+func addWeightedPairs(G *Graph, pairs []struct {
+	Pair   [2]string `json:"pair"`
+	Weight int       `json:"weight"`
+}) *Graph {
 	for _, p := range pairs {
 		lu, lv, lw := Label(p.Pair[0]), Label(p.Pair[1]), p.Weight
-		if G.V[lu] == nil {
-			u := NewVertex(lu)
-			G.V[lu] = u
-			G.VNum++
+		G.AddVertex(lu)
+		G.AddVertex(lv)
+		if err := G.AddEdge(lu, lv, lw); err == ErrEdgeExists {
+			G.SetWeight(lu, lv, lw)
 		}
-		u := G.V[lu]
-		u.Adj = append(u.Adj, Label(lv))
-		if G.V[lv] == nil {
-			v := NewVertex(lv)
-			G.V[lv] = v
-			G.VNum++
-			edge, weight := NewWeightedEdge(u, v, lw)
-			G.E[edge] = weight
-		} else {
-			edge, weight := NewWeightedEdge(u, G.V[lv], lw)
-			G.E[edge] = weight
-		}
-		G.ENum++
 	}
 	return G
 }
 
 // Transpose of Graph G, Gt is graph G with all its
 // edges reversed Transpose of G = (V, E) is graph Gt = (V, Et)
+// where each edge (v, u) ∈ Et keeps the weight of (u, v) ∈ E
+// The transpose of an undirected graph is a copy of it
 func Transpose(g *Graph) *Graph {
 	Gt := NewGraph()
+	Gt.Undirected = g.Undirected
+	for l := range g.V {
+		Gt.AddVertex(l)
+	}
 	for _, ov := range g.V {
-		if Gt.V[ov.Label] == nil {
-			Gt.V[ov.Label] = NewVertex(ov.Label)
-			Gt.VNum++
-		}
-		u := Gt.V[ov.Label]
 		for _, j := range ov.Adj {
-			u.Adj = append(u.Adj, j)
-			if Gt.V[j] == nil {
-				v := NewVertex(j)
-				Gt.V[j] = v
-				Gt.VNum++
-				edge := NewEdge(v, u)
-				Gt.E[edge] = 1
-				Gt.ENum++
-			} else {
-				edge := NewEdge(Gt.V[j], u)
-				Gt.E[edge] = 1
-				Gt.ENum++
-			}
+			Gt.AddEdge(j, ov.Label, g.weight(NewEdge(ov, g.V[j])))
 		}
 	}
 	return Gt
 }
 
+// Paths holds the result of a search from a single source s, such as
+// BFS, Dijkstra or BellmanFord. Distance maps each vertex v reached
+// from s to d(s, v) - the number of edges, or the weight, of the
+// shortest path found from s to v - and Predecessor maps each vertex
+// reached other than s to the vertex preceding it on that path (π)
+// A vertex that was not reached from s is in neither map
+type Paths struct {
+	Source      Label
+	Distance    map[Label]int
+	Predecessor map[Label]Label
+}
+
+// newPaths returns the paths of a search from s
+// which has only reached s itself
+func newPaths(s Label) *Paths {
+	return &Paths{
+		Source:      s,
+		Distance:    map[Label]int{s: 0},
+		Predecessor: make(map[Label]Label),
+	}
+}
+
+// Reached returns true if v was reached from the source
+func (p *Paths) Reached(v Label) bool {
+	_, ok := p.Distance[v]
+	return ok
+}
+
 // BFS assumes the input graph is represented using adjacency lists
 // the result should be the same for each source as the order of
 // visit is always mantained
-func BFS(G *Graph, l Label) bool {
-	s, ok := G.V[l]
-	if !ok {
-		return false
+// A vertex is white until it is first reached and given a distance,
+// the Paths returned hold the breadth-first tree rooted at l
+func BFS(G *Graph, l Label) (*Paths, error) {
+	if _, ok := G.V[l]; !ok {
+		return nil, ErrVertexNotFound
 	}
-	s.color = gray
-	Q := []*Vertex{s}
+	p := newPaths(l)
+	Q := []Label{l}
 	for len(Q) > 0 {
 		u := Q[0]
 		Q = Q[1:]
-		for _, v := range u.Adj {
-			t := G.V[v]
-			if t.color == white {
-				t.color = gray
-				t.Distance = u.Distance + 1
-				t.Predecessor = u
-				Q = append(Q, t)
+		for _, v := range G.V[u].Adj {
+			if !p.Reached(v) {
+				p.Distance[v] = p.Distance[u] + 1
+				p.Predecessor[v] = u
+				Q = append(Q, v)
 			}
 		}
-		u.color = black
 	}
-	return true
+	return p, nil
 }
 
-// PrintPath returns a slice of vertices identified by theier labels
-// on a shortest path from s to v,
-// assuming that BFS has already been computed
-func PrintPath(out io.Writer, G *Graph, a, b Label) bool {
-	s, ok1 := G.V[a]
-	v, ok2 := G.V[b]
-	if !ok1 && !ok2 {
-		return false
+// Diameter of a graph G = (V, E) gives the largest of all
+// shortest-path distances in the graph, computed with a BFS
+// from every vertex in an order 0(V(V+E))-time
+func (G *Graph) Diameter() int {
+	var max int
+	for l := range G.V {
+		p, _ := BFS(G, l)
+		for _, d := range p.Distance {
+			if d > max {
+				max = d
+			}
+		}
 	}
-	if v == s {
-		fmt.Fprintln(out, s)
-	} else if v.Predecessor == nil {
-		fmt.Fprintf(out, "no path from %s to %v\n", a, b)
-	} else {
-		PrintPath(out, G, s.Label, v.Predecessor.Label)
-		fmt.Fprintln(out, v)
+	return max
+}
+
+// DFSForest holds the depth-first forest produced by a depth-first
+// search of G. Predecessor maps each vertex, other than the roots of
+// the trees, to the vertex it was discovered from and Depth maps each
+// vertex to its depth in its tree. Discovery and Finish hold the
+// timestamps recording when each vertex was first discovered (grayed)
+// and when the search finished examining its adjacency list
+// (blackened), and Time is the last timestamp given
+type DFSForest struct {
+	Predecessor map[Label]Label
+	Depth       map[Label]int
+	Discovery   map[Label]int
+	Finish      map[Label]int
+	Time        int
+}
+
+// newDFSForest returns an empty depth-first forest of G
+func newDFSForest(G *Graph) *DFSForest {
+	return &DFSForest{
+		Predecessor: make(map[Label]Label),
+		Depth:       make(map[Label]int, len(G.V)),
+		Discovery:   make(map[Label]int, len(G.V)),
+		Finish:      make(map[Label]int, len(G.V)),
 	}
-	return true
 }
 
-// Diameter of a tree T = (V, E) gives the largest of all
-// shortest-path distances in the tree
-// it assumes a BFS has already been computed for the graph
-func (G *Graph) Diameter() int {
-	var max int
-	for _, v := range G.V {
-		if v.Distance > max {
-			max = v.Distance
+// color returns the color of the vertex labeled l,
+// derived from its timestamps
+func (f *DFSForest) color(l Label) color {
+	if _, ok := f.Finish[l]; ok {
+		return black
+	}
+	if _, ok := f.Discovery[l]; ok {
+		return gray
+	}
+	return white
+}
+
+// kind returns the kind of the edge (u, v) when it is first explored
+// from u, by the color of v and, for a black v, by whether v was
+// discovered after u
+func (f *DFSForest) kind(u, v Label) EdgeKind {
+	switch f.color(v) {
+	case white:
+		return Tree
+	case gray:
+		return Back
+	}
+	if f.Discovery[u] < f.Discovery[v] {
+		return Forward
+	}
+	return Cross
+}
+
+// depthFirst searches G choosing new sources in increasing order
+// of their labels. The edge function, if given, is executed for
+// every edge (u, v) explored, before v is visited when it is white,
+// and the vertex function, if given, for every vertex finished
+func depthFirst(G *Graph, vertexFn func(*Vertex), edgeFn func(Edge, EdgeKind)) *DFSForest {
+	f := newDFSForest(G)
+	var dfsVisit func(*Vertex)
+	dfsVisit = func(u *Vertex) {
+		f.Time++
+		f.Discovery[u.Label] = f.Time
+		for _, j := range u.Adj {
+			v := G.V[j]
+			if edgeFn != nil {
+				edgeFn(NewEdge(u, v), f.kind(u.Label, j))
+			}
+			if f.color(j) == white {
+				f.Predecessor[j] = u.Label
+				f.Depth[j] = f.Depth[u.Label] + 1
+				dfsVisit(v)
+			}
+		}
+		f.Time++
+		f.Finish[u.Label] = f.Time
+		if vertexFn != nil {
+			vertexFn(u)
 		}
 	}
-	return max
+	for _, l := range G.labels() {
+		if f.color(l) == white {
+			f.Depth[l] = 0
+			dfsVisit(G.V[l])
+		}
+	}
+	return f
 }
 
 // DFS strategy searches deeper into the graph whenever
@@ -295,8 +388,8 @@ func (G *Graph) Diameter() int {
 // for that source.
 // When depth-first search discovers a vertex v during a scan of
 // the adjacency list of an already discovered vertex u,
-// it records this event by setting v's predecessor attribute
-// v{Predecessor} to u. The predecessor subgraph produced may
+// it records this event by setting v's predecessor
+// Predecessor[v] to u. The predecessor subgraph produced may
 // be composed of several trees because the search may repeat
 // from multiple sources.
 // Notes to remember when classifying an edge
@@ -305,140 +398,252 @@ func (G *Graph) Diameter() int {
 // 1. `white` indicates a tree edge
 // 2. `gray` indicates a back edge,
 // 3. `black` indicates a forward or cross edge
-func DFS(G *Graph) int {
-	var time int
-	var dfsVisit func(*Vertex)
-	dfsVisit = func(u *Vertex) {
-		u.color = gray
-		time++
-		u.dstamp = time
+// ClassifyEdges reports the kind of every edge this way
+func DFS(G *Graph) *DFSForest {
+	return depthFirst(G, nil, nil)
+}
+
+// VertexWalk receives a second parameter fn(e *Vertex) that
+// is executed for every vertex completely visited
+func VertexWalk(G *Graph, fn func(e *Vertex)) *DFSForest {
+	return depthFirst(G, fn, nil)
+}
+
+// EdgeWalk receives a second parameter fn(e *Edge) that
+// is executed for every edge during a depth first
+// encountered search of a graph G
+func EdgeWalk(G *Graph, fn func(e Edge)) *DFSForest {
+	return depthFirst(G, nil, func(e Edge, _ EdgeKind) { fn(e) })
+}
+
+// ClassifyEdges receives a second parameter fn(e Edge, k EdgeKind)
+// that is executed for every edge during a depth first search of a
+// graph G, with the kind of the edge in the depth-first forest
+// An edge {u, v} of an undirected graph is reported once, the first
+// time it is explored, and is always either a tree or a back edge
+// A directed graph is acyclic if and only if it has no back edges
+func ClassifyEdges(G *Graph, fn func(e Edge, k EdgeKind)) *DFSForest {
+	if !G.Undirected {
+		return depthFirst(G, nil, fn)
+	}
+	seen := make(map[Edge]bool, len(G.E))
+	return depthFirst(G, nil, func(e Edge, k EdgeKind) {
+		if key := G.key(e); !seen[key] {
+			seen[key] = true
+			fn(e, k)
+		}
+	})
+}
+
+// TopoSort of a DAG produces a linear ordering of all vertices
+// such that if G contains an edge (u, v), then u appears before v
+// in the ordering - maintaining precedence
+// A graph with a cycle cannot produce such an ordering, it is detected
+// when the depth-first search explores an edge (u, v) to a gray vertex v,
+// a back edge, and reported as a *CycleError naming the vertices
+// on the path from v to u in the depth-first tree
+// An undirected graph has no such ordering, ErrUndirected is returned
+func TopoSort(G *Graph) (*list.LinkedList, error) {
+	if G.Undirected {
+		return nil, ErrUndirected
+	}
+	l := list.NewLinkedList()
+	f := newDFSForest(G)
+	var cycle []Label
+	var dfsVisit func(*Vertex) bool
+	dfsVisit = func(u *Vertex) bool {
+		f.Time++
+		f.Discovery[u.Label] = f.Time
 		for _, j := range u.Adj {
-			v := G.V[j]
-			if v.color == white {
-				v.Predecessor = u
-				v.Distance = u.Distance + 1
-				dfsVisit(v)
+			switch f.color(j) {
+			case white:
+				f.Predecessor[j] = u.Label
+				f.Depth[j] = f.Depth[u.Label] + 1
+				if !dfsVisit(G.V[j]) {
+					return false
+				}
+			case gray:
+				cycle = []Label{j}
+				var path []Label
+				for w := u.Label; w != j; w = f.Predecessor[w] {
+					path = append(path, w)
+				}
+				for i := len(path) - 1; i >= 0; i-- {
+					cycle = append(cycle, path[i])
+				}
+				return false
 			}
 		}
-		u.color = black
-		time++
-		u.fstamp = time
+		f.Time++
+		f.Finish[u.Label] = f.Time
+		l.AddHead(string(u.Label))
+		return true
 	}
-	for _, u := range G.V {
-		if u.color == white {
-			dfsVisit(u)
+	for _, j := range G.labels() {
+		if f.color(j) == white && !dfsVisit(G.V[j]) {
+			return nil, &CycleError{Cycle: cycle}
 		}
 	}
-	return time
+	return l, nil
 }
 
-// VertexWalk receives a second parameter fn(e *Vertex) that
-// is executed for every vertex completely visited
-func VertexWalk(G *Graph, fn func(e *Vertex)) int {
-	var time int
+// SCC returns the strongly connected components of G using two
+// depth-first searches. The first computes the finishing times of all
+// vertices in G, the second searches the transpose Gt considering the
+// vertices in order of decreasing finishing time, so that each tree of
+// its depth-first forest is a strongly connected component.
+// The components are returned in topological order of the component
+// graph of G, with the labels of each component sorted
+// This is achieved in an order 0(V+E)-time
+func SCC(G *Graph) [][]Label {
+	var order []Label
+	VertexWalk(G, func(v *Vertex) {
+		order = append(order, v.Label)
+	})
+	Gt := Transpose(G)
+	var components [][]Label
+	var component []Label
+	visited := make(map[Label]bool, len(G.V))
 	var dfsVisit func(*Vertex)
 	dfsVisit = func(u *Vertex) {
-		u.color = gray
-		time++
-		u.dstamp = time
+		visited[u.Label] = true
+		component = append(component, u.Label)
 		for _, j := range u.Adj {
-			v := G.V[j]
-			if v.color == white {
-				v.Predecessor = u
-				v.Distance = u.Distance + 1
-				dfsVisit(v)
+			if !visited[j] {
+				dfsVisit(Gt.V[j])
 			}
 		}
-		u.color = black
-		time++
-		u.fstamp = time
-		fn(u)
 	}
-	for _, u := range G.V {
-		if u.color == white {
-			dfsVisit(u)
+	for i := len(order) - 1; i >= 0; i-- {
+		if !visited[order[i]] {
+			component = nil
+			dfsVisit(Gt.V[order[i]])
+			sortLabels(component)
+			components = append(components, component)
 		}
 	}
-	return time
+	return components
 }
 
-// EdgeWalk receives a second parameter fn(e *Edge) that
-// is executed for every edge during a depth first
-// encountered search of a graph G
-func EdgeWalk(G *Graph, fn func(e Edge)) int {
+// TarjanSCC returns the strongly connected components of G in a
+// single depth-first search without building the transpose of G.
+// Each vertex is given an index in order of discovery and a low-link,
+// the smallest index of any vertex on the stack reachable from it,
+// vertices are pushed on a stack as they are discovered and a vertex
+// whose low-link equals its index is the root of a component made of
+// the vertices above it on the stack.
+// Components are found in reverse topological order of the component
+// graph, they are returned in topological order as with SCC
+// This is achieved in an order 0(V+E)-time
+func TarjanSCC(G *Graph) [][]Label {
 	var time int
-	var dfsVisit func(*Vertex)
-	dfsVisit = func(u *Vertex) {
-		u.color = gray
+	index := make(map[Label]int, len(G.V))
+	low := make(map[Label]int, len(G.V))
+	onStack := make(map[Label]bool)
+	var stack []Label
+	var components [][]Label
+	var strongConnect func(*Vertex)
+	strongConnect = func(u *Vertex) {
 		time++
-		u.dstamp = time
-		for _, j := range u.Adj {
-			v := G.V[j]
-			if v.color == white {
-				fn(NewEdge(u, v))
-				v.Predecessor = u
-				v.Distance = u.Distance + 1
-				dfsVisit(v)
-			} else {
-				fn(NewEdge(u, v))
+		index[u.Label], low[u.Label] = time, time
+		stack = append(stack, u.Label)
+		onStack[u.Label] = true
+		for _, v := range u.Adj {
+			if _, ok := index[v]; !ok {
+				strongConnect(G.V[v])
+				if low[v] < low[u.Label] {
+					low[u.Label] = low[v]
+				}
+			} else if onStack[v] && index[v] < low[u.Label] {
+				low[u.Label] = index[v]
 			}
 		}
-		u.color = black
-		time++
-		u.fstamp = time
+		if low[u.Label] != index[u.Label] {
+			return
+		}
+		var component []Label
+		for {
+			n := len(stack) - 1
+			v := stack[n]
+			stack = stack[:n]
+			onStack[v] = false
+			component = append(component, v)
+			if v == u.Label {
+				break
+			}
+		}
+		sortLabels(component)
+		components = append(components, component)
 	}
-	for _, u := range G.V {
-		if u.color == white {
-			dfsVisit(u)
+	for _, l := range G.labels() {
+		if _, ok := index[l]; !ok {
+			strongConnect(G.V[l])
 		}
 	}
-	return time
-}
-
-// TopoSort of a DAG produces a linear ordering of all vertices
-// such that if G contains an edge (u, v), then u appears before v
-// in the ordering - maintaining precedence
-// A graph with a cycle cannot produce such an ordering
-func TopoSort(G *Graph) *list.LinkedList {
-	l := list.NewLinkedList()
-	VertexWalk(G, func(v *Vertex) {
-		l.AddHead(string(v.Label))
-	})
-	return l
+	for i, j := 0, len(components)-1; i < j; i, j = i+1, j-1 {
+		components[i], components[j] = components[j], components[i]
+	}
+	return components
 }
 
-// DFStranspose indicates a forward or cross edge
-func DFStranspose(G *Graph, t int) int {
-	time := t
-	var dfsVisit func(*Vertex)
-	dfsVisit = func(u *Vertex) {
-		u.color = gray
-		u.dstamp = time
-		time--
-		for _, j := range u.Adj {
-			v := G.V[j]
-			if v.color == white {
-				v.Predecessor = u
-				v.Distance = u.Distance + 1
-				dfsVisit(v)
-			}
+// Condensation returns the component graph of G, a DAG obtained by
+// contracting each strongly connected component of G to a single
+// vertex. The vertices of the component graph are labeled with the
+// position of their component in the topological order given by SCC
+// ("0", "1", ...) and it holds an edge (Ci, Cj) if G holds an edge
+// (u, v) for some u ∈ Ci and v ∈ Cj, i != j.
+// It also returns a map from the label of each vertex of G to the
+// label of its component
+func Condensation(G *Graph) (*Graph, map[Label]Label) {
+	components := SCC(G)
+	C := make(map[Label]Label, len(G.V))
+	pairs := make([][2]string, 0, len(components))
+	for i, c := range components {
+		id := strconv.Itoa(i)
+		for _, l := range c {
+			C[l] = Label(id)
 		}
-		u.color = black
-		u.fstamp = time
-		time--
+		pairs = append(pairs, [2]string{id, ""})
 	}
-	for _, u := range G.V {
-		if u.color == white {
-			dfsVisit(u)
+	seen := make(map[[2]Label]bool)
+	var edges [][2]string
+	for _, c := range components {
+		for _, l := range c {
+			for _, j := range G.V[l].Adj {
+				e := [2]Label{C[l], C[j]}
+				if e[0] == e[1] || seen[e] {
+					continue
+				}
+				seen[e] = true
+				edges = append(edges, [2]string{string(e[0]), string(e[1])})
+			}
 		}
 	}
-	return time
+	return BuildGraph(append(pairs, edges...)), C
 }
 
-// SCC (G *Graph)
-func SCC(G *Graph) *Graph {
-	time := DFS(G)
-	Gt := Transpose(G)
-	time = DFStranspose(Gt, time)
-	return Gt
+// labels returns the labels of all vertices in G in sorted order
+func (G *Graph) labels() []Label {
+	l := make([]Label, 0, len(G.V))
+	for j := range G.V {
+		l = append(l, j)
+	}
+	sortLabels(l)
+	return l
+}
+
+// sortLabels sorts a slice of labels in increasing order
+func sortLabels(l []Label) {
+	sort.Slice(l, func(i, j int) bool { return l[i] < l[j] })
+}
+
+// sortEdges sorts edges by the label of u and then by the label of v,
+// to keep the search order independent of the map order of G.E
+func sortEdges(e []Edge) {
+	sort.Slice(e, func(i, j int) bool {
+		if e[i].U.Label != e[j].U.Label {
+			return e[i].U.Label < e[j].U.Label
+		}
+		return e[i].V.Label < e[j].V.Label
+	})
 }
@@ -1,9 +1,11 @@
 package graph
 
 import (
-	"bytes"
 	"encoding/json"
+	"fmt"
 	"os"
+	"reflect"
+	"sync"
 	"testing"
 )
 
@@ -101,11 +103,14 @@ func TestBFS(t *testing.T) {
 		t.Fatalf("decoding graph: %v", err)
 	}
 	G := BuildGraph(param)
-	BFS(G, Label("A"))
-	t.Log("bfs: \n", G)
+	p, err := BFS(G, Label("A"))
+	if err != nil {
+		t.Fatalf("bfs: %v", err)
+	}
+	t.Log("bfs: \n", p.Distance)
 }
 
-func TestPrintPath(t *testing.T) {
+func TestPathTo(t *testing.T) {
 	var param [][2]string
 	f, err := os.Open("testdata/dgraph.json")
 	if err != nil {
@@ -117,10 +122,15 @@ func TestPrintPath(t *testing.T) {
 		t.Fatalf("decoding graph: %v", err)
 	}
 	G := BuildGraph(param)
-	BFS(G, Label("A"))
-	out := bytes.NewBuffer(make([]byte, 0, len(param)))
-	PrintPath(out, G, Label("A"), Label("R"))
-	t.Log("printpath:\n", out.String())
+	p, err := BFS(G, Label("A"))
+	if err != nil {
+		t.Fatalf("bfs: %v", err)
+	}
+	path, err := PathTo(p, Label("R"))
+	if err != nil {
+		t.Fatalf("path: %v", err)
+	}
+	t.Log("path:\n", path)
 }
 
 func TestDiameter(t *testing.T) {
@@ -135,7 +145,6 @@ func TestDiameter(t *testing.T) {
 		t.Fatalf("decoding graph: %v", err)
 	}
 	G := BuildGraph(param)
-	BFS(G, Label(param[0][0]))
 	t.Log("diameter: \n", G.Diameter())
 }
 
@@ -151,8 +160,8 @@ func TestDFS(t *testing.T) {
 		t.Fatalf("decoding graph: %v", err)
 	}
 	G := BuildGraph(param)
-	DFS(G)
-	t.Log("dfs recursive: \n", G)
+	forest := DFS(G)
+	t.Log("dfs recursive: \n", forest.Discovery, forest.Finish)
 }
 
 func TestTopSort(t *testing.T) {
@@ -167,7 +176,10 @@ func TestTopSort(t *testing.T) {
 		t.Fatalf("decoding graph: %v", err)
 	}
 	G := BuildGraph(param)
-	l := TopoSort(G)
+	l, err := TopoSort(G)
+	if err != nil {
+		t.Fatalf("topological sort: %v", err)
+	}
 	t.Log("dress graph \n", G)
 	t.Log("topological sort \n", l)
 }
@@ -187,8 +199,161 @@ func TestDijsktra(t *testing.T) {
 		t.Fatalf("decoding graph: %v", err)
 	}
 	G := BuildWeightedGraph(param)
-	sp := Dijkstra(G, Label("s"))
-	for _, v := range sp {
-		t.Log(v)
+	sp, err := Dijkstra(G, Label("s"))
+	if err != nil {
+		t.Fatalf("dijkstra: %v", err)
+	}
+	for v, d := range sp.Distance {
+		t.Log(v, d, sp.Predecessor[v])
+	}
+}
+
+// clrsSCC is the graph of figure 22.9 in CLRS
+var clrsSCC = [][2]string{
+	{"a", "b"}, {"b", "c"}, {"b", "e"}, {"b", "f"},
+	{"c", "d"}, {"c", "g"}, {"d", "c"}, {"d", "h"},
+	{"e", "a"}, {"e", "f"}, {"f", "g"}, {"g", "f"},
+	{"g", "h"}, {"h", "h"},
+}
+
+func TestSCC(t *testing.T) {
+	expected := [][]Label{{"a", "b", "e"}, {"c", "d"}, {"f", "g"}, {"h"}}
+	for name, scc := range map[string]func(*Graph) [][]Label{
+		"kosaraju": SCC,
+		"tarjan":   TarjanSCC,
+	} {
+		G := BuildGraph(clrsSCC)
+		components := scc(G)
+		if len(components) != len(expected) {
+			t.Fatalf("%s: expected %v, got %v", name, expected, components)
+		}
+		// the component graph of clrsSCC is a chain, so it has
+		// a single topological order
+		for i, c := range components {
+			if fmt.Sprint(c) != fmt.Sprint(expected[i]) {
+				t.Errorf("%s: expected component %d to be %v, got %v", name, i, expected[i], c)
+			}
+		}
+	}
+}
+
+func TestCondensation(t *testing.T) {
+	G := BuildGraph(append([][2]string{{"i", ""}}, clrsSCC...))
+	Gc, C := Condensation(G)
+	if Gc.VNum != 5 || Gc.ENum != 5 {
+		t.Errorf("expected 5 vertices and 5 edges, got %d and %d", Gc.VNum, Gc.ENum)
+	}
+	for _, l := range []Label{"a", "b", "e"} {
+		if C[l] != C["a"] {
+			t.Errorf("expected %s to be in component %s, got %s", l, C["a"], C[l])
+		}
+	}
+	if C["a"] == C["c"] {
+		t.Errorf("expected a and c to be in different components")
+	}
+	for e := range Gc.E {
+		if e.U == e.V {
+			t.Errorf("expected no self loop in the component graph, got %s", e)
+		}
+	}
+	t.Log("condensation:\n", Gc)
+}
+
+// clrsBFS is the undirected graph of figure 22.3 in CLRS
+// with each edge stored in both directions
+var clrsBFS = [][2]string{
+	{"r", "s"}, {"s", "r"}, {"r", "v"}, {"v", "r"},
+	{"s", "w"}, {"w", "s"}, {"w", "t"}, {"t", "w"},
+	{"w", "x"}, {"x", "w"}, {"t", "x"}, {"x", "t"},
+	{"t", "u"}, {"u", "t"}, {"x", "u"}, {"u", "x"},
+	{"x", "y"}, {"y", "x"}, {"u", "y"}, {"y", "u"},
+}
+
+func TestSearchReentrant(t *testing.T) {
+	G := BuildGraph(clrsBFS)
+	expected := map[Label]int{"r": 1, "s": 0, "t": 2, "u": 3, "v": 2, "w": 1, "x": 2, "y": 3}
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			var p *Paths
+			if i%2 == 0 {
+				p, _ = BFS(G, "s")
+			} else {
+				p, _ = Dijkstra(G, "s")
+			}
+			for l, d := range expected {
+				if p.Distance[l] != d {
+					t.Errorf("expected distance of %s to be %d, got %d", l, d, p.Distance[l])
+				}
+			}
+			DFS(G)
+		}(i)
+	}
+	wg.Wait()
+	if d := G.Diameter(); d != 5 {
+		t.Errorf("expected diameter %d, got %d", 5, d)
+	}
+	p, _ := BFS(G, "s")
+	if path, err := PathTo(p, "y"); err != nil || path.Hops != 3 {
+		t.Errorf("expected a path of 3 edges, got %v: %v", path, err)
+	}
+}
+
+func TestDFSTimestamps(t *testing.T) {
+	G := BuildGraph(clrsSCC)
+	f := DFS(G)
+	if f.Time != 2*G.VNum {
+		t.Errorf("expected last timestamp %d, got %d", 2*G.VNum, f.Time)
+	}
+	// parenthesis theorem: the interval of a descendant
+	// is contained in the interval of its ancestor
+	for v, u := range f.Predecessor {
+		if f.Discovery[u] >= f.Discovery[v] || f.Finish[v] >= f.Finish[u] {
+			t.Errorf("expected [%d, %d] of %s to nest in [%d, %d] of %s",
+				f.Discovery[v], f.Finish[v], v, f.Discovery[u], f.Finish[u], u)
+		}
+		if f.Depth[v] != f.Depth[u]+1 {
+			t.Errorf("expected depth of %s to be one more than %s", v, u)
+		}
+	}
+}
+
+func TestClassifyEdges(t *testing.T) {
+	// the graph of figure 22.5 in CLRS
+	G := BuildGraph([][2]string{
+		{"s", "z"}, {"s", "w"}, {"t", "v"}, {"t", "u"},
+		{"u", "v"}, {"u", "t"}, {"v", "s"}, {"v", "w"},
+		{"w", "x"}, {"x", "z"}, {"y", "x"}, {"z", "y"}, {"z", "w"},
+	})
+	expected := map[string]EdgeKind{
+		"sz": Tree, "zy": Tree, "yx": Tree, "zw": Tree, "tv": Tree, "tu": Tree,
+		"xz": Back, "ut": Back,
+		"sw": Forward,
+		"wx": Cross, "vs": Cross, "vw": Cross, "uv": Cross,
+	}
+	got := make(map[string]EdgeKind)
+	ClassifyEdges(G, func(e Edge, k EdgeKind) {
+		got[string(e.U.Label)+string(e.V.Label)] = k
+	})
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+
+	if s := Cross.String(); s != "cross" {
+		t.Errorf("expected cross, got %s", s)
+	}
+	if s := EdgeKind(7).String(); s != "EdgeKind(7)" {
+		t.Errorf("expected EdgeKind(7), got %s", s)
+	}
+
+	U := BuildUndirectedGraph([][2]string{{"a", "b"}, {"b", "c"}, {"c", "a"}, {"c", "d"}})
+	kinds := make(map[EdgeKind]int)
+	ClassifyEdges(U, func(e Edge, k EdgeKind) {
+		kinds[k]++
+	})
+	if kinds[Tree] != 3 || kinds[Back] != 1 || len(kinds) != 2 {
+		t.Errorf("expected 3 tree edges and 1 back edge, got %v", kinds)
 	}
 }
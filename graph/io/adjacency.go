@@ -0,0 +1,86 @@
+package io
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/willpoint/algor/graph"
+)
+
+// neighbor splits an adjacency list entry v:w into the label v and
+// the weight w, an entry whose text after its last colon is not an
+// integer is a label with the unit weight
+func neighbor(s string) (graph.Label, int) {
+	if i := strings.LastIndexByte(s, ':'); i > 0 {
+		if w, err := strconv.Atoi(s[i+1:]); err == nil {
+			return graph.Label(s[:i]), w
+		}
+	}
+	return graph.Label(s), 1
+}
+
+// ReadAdjacencyList reads an adjacency list from r into G, which may
+// be directed or undirected. Each line holds the label of a vertex u
+// followed by the vertices adjacent to it, each written as v or v:w
+// for an edge (u, v) of weight w
+//
+//	a b:4 c
+//	b c
+//	c
+//
+// An edge of an undirected graph may be listed at both of its vertices.
+// Blank lines and comments from # to the end of a line are skipped
+func ReadAdjacencyList(r io.Reader, G *graph.Graph) error {
+	lr := newLineReader(r)
+	for {
+		f, err := lr.fields()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		u := graph.Label(f[0])
+		G.AddVertex(u)
+		for _, s := range f[1:] {
+			v, w := neighbor(s)
+			if v == "" {
+				return lr.errorf("missing label in %q", s)
+			}
+			addEdge(G, u, v, w)
+		}
+	}
+}
+
+// WriteAdjacencyList writes G to w as an adjacency list read by
+// ReadAdjacencyList, one line for each vertex in order of labels
+// listing its adjacent vertices in the order of its adjacency list
+// It returns ErrInvalidLabel for a label that is empty, or holds
+// white space or #
+func WriteAdjacencyList(w io.Writer, G *graph.Graph) error {
+	for l := range G.V {
+		if err := checkLabel(l); err != nil {
+			return err
+		}
+	}
+	b := bufio.NewWriter(w)
+	for _, l := range labels(G) {
+		u := G.V[l]
+		b.WriteString(string(l))
+		for _, j := range u.Adj {
+			wt, _ := G.Weight(l, j)
+			// a label holding a colon is always given its weight
+			// so that it is not taken for one
+			if wt != 1 || strings.Contains(string(j), ":") {
+				fmt.Fprintf(b, " %s:%d", j, wt)
+			} else {
+				fmt.Fprintf(b, " %s", j)
+			}
+		}
+		b.WriteByte('\n')
+	}
+	return b.Flush()
+}
@@ -0,0 +1,39 @@
+package io
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/willpoint/algor/graph"
+)
+
+func TestAdjacencyList(t *testing.T) {
+	for _, undirected := range []bool{false, true} {
+		G := weighted(undirected)
+		G.AddVertex("host:8080")
+		G.AddEdge("e", "host:8080", 1)
+		var b bytes.Buffer
+		if err := WriteAdjacencyList(&b, G); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		H := graph.NewGraph()
+		H.Undirected = undirected
+		if err := ReadAdjacencyList(&b, H); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		sameGraph(t, G, H)
+	}
+	G := graph.NewGraph()
+	if err := ReadAdjacencyList(strings.NewReader("a b:2 c\nc a:-1\nd\n"), G); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	H := graph.NewGraph()
+	for _, l := range []graph.Label{"a", "b", "c", "d"} {
+		H.AddVertex(l)
+	}
+	H.AddEdge("a", "b", 2)
+	H.AddEdge("a", "c", 1)
+	H.AddEdge("c", "a", -1)
+	sameGraph(t, H, G)
+}
@@ -0,0 +1,11 @@
+/*
+Package io reads and writes graph.Graph values in common text
+formats: whitespace separated edge lists, adjacency lists, GraphML
+and a JSON schema of its own. Readers stream their input, adding
+each vertex and edge to the graph as it is read, so that a large
+graph never has to be held in memory as a slice of pairs first.
+Writers order vertices and edges by label so their output is stable.
+Since the package shares its name with the standard library io
+package it is best imported under another name, such as gio
+*/
+package io
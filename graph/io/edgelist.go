@@ -0,0 +1,167 @@
+package io
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/willpoint/algor/graph"
+)
+
+var (
+	// ErrInvalidLabel occurs when writing a label that cannot be
+	// represented in a whitespace separated format - an empty label
+	// or one holding white space
+	ErrInvalidLabel = errors.New("label is empty or holds white space")
+)
+
+// lineReader reads a text format one line at a time, skipping
+// blank lines and comments starting with #
+type lineReader struct {
+	r    *bufio.Reader
+	line int
+}
+
+func newLineReader(r io.Reader) *lineReader {
+	return &lineReader{r: bufio.NewReader(r)}
+}
+
+// fields returns the fields of the next line holding any
+// and io.EOF once the input is exhausted
+func (lr *lineReader) fields() ([]string, error) {
+	for {
+		s, err := lr.r.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return nil, err
+		}
+		if err == io.EOF && s == "" {
+			return nil, io.EOF
+		}
+		lr.line++
+		if i := strings.IndexByte(s, '#'); i >= 0 {
+			s = s[:i]
+		}
+		if f := strings.Fields(s); len(f) > 0 {
+			return f, nil
+		}
+	}
+}
+
+func (lr *lineReader) errorf(format string, a ...interface{}) error {
+	return fmt.Errorf("line %d: %s", lr.line, fmt.Sprintf(format, a...))
+}
+
+// addEdge adds the edge (u, v) to G along with its vertices, an
+// edge read more than once takes the last weight
+func addEdge(G *graph.Graph, u, v graph.Label, w int) {
+	G.AddVertex(u)
+	G.AddVertex(v)
+	if err := G.AddEdge(u, v, w); err == graph.ErrEdgeExists {
+		G.SetWeight(u, v, w)
+	}
+}
+
+// ReadEdgeList reads an edge list from r into G, which may be directed
+// or undirected. Each line holds an edge as the labels of its two
+// vertices and an optional integer weight (1 if omitted) separated by
+// white space, or the label of a single vertex with no edges
+//
+//	a b 4
+//	b c
+//	d
+//
+// Blank lines and comments from # to the end of a line are skipped
+func ReadEdgeList(r io.Reader, G *graph.Graph) error {
+	lr := newLineReader(r)
+	for {
+		f, err := lr.fields()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		switch len(f) {
+		case 1:
+			G.AddVertex(graph.Label(f[0]))
+		case 2, 3:
+			w := 1
+			if len(f) == 3 {
+				if w, err = strconv.Atoi(f[2]); err != nil {
+					return lr.errorf("weight %q is not an integer", f[2])
+				}
+			}
+			addEdge(G, graph.Label(f[0]), graph.Label(f[1]), w)
+		default:
+			return lr.errorf("expected at most 3 fields, found %d", len(f))
+		}
+	}
+}
+
+// checkLabel fails for labels that cannot be written
+// in a whitespace separated format
+func checkLabel(l graph.Label) error {
+	if l == "" || strings.IndexFunc(string(l), unicode.IsSpace) >= 0 || strings.Contains(string(l), "#") {
+		return ErrInvalidLabel
+	}
+	return nil
+}
+
+// labels returns the labels of the vertices of G in sorted order
+func labels(G *graph.Graph) []graph.Label {
+	l := make([]graph.Label, 0, len(G.V))
+	for j := range G.V {
+		l = append(l, j)
+	}
+	sort.Slice(l, func(i, j int) bool { return l[i] < l[j] })
+	return l
+}
+
+// edges returns the edges of G ordered by the labels of their vertices
+func edges(G *graph.Graph) []graph.Edge {
+	e := make([]graph.Edge, 0, len(G.E))
+	for j := range G.E {
+		e = append(e, j)
+	}
+	sort.Slice(e, func(i, j int) bool {
+		if e[i].U.Label != e[j].U.Label {
+			return e[i].U.Label < e[j].U.Label
+		}
+		return e[i].V.Label < e[j].V.Label
+	})
+	return e
+}
+
+// WriteEdgeList writes G to w as an edge list read by ReadEdgeList,
+// one line per edge with its weight unless it is the unit weight,
+// followed by one line for each vertex with no edge
+// It returns ErrInvalidLabel for a label that is empty, or holds
+// white space or #
+func WriteEdgeList(w io.Writer, G *graph.Graph) error {
+	for l := range G.V {
+		if err := checkLabel(l); err != nil {
+			return err
+		}
+	}
+	b := bufio.NewWriter(w)
+	touched := make(map[graph.Label]bool, len(G.V))
+	for _, e := range edges(G) {
+		touched[e.U.Label], touched[e.V.Label] = true, true
+		if wt := G.E[e]; wt != 1 {
+			fmt.Fprintf(b, "%s %s %d\n", e.U.Label, e.V.Label, wt)
+		} else {
+			fmt.Fprintf(b, "%s %s\n", e.U.Label, e.V.Label)
+		}
+	}
+	for _, l := range labels(G) {
+		if !touched[l] {
+			fmt.Fprintln(b, l)
+		}
+	}
+	return b.Flush()
+}
@@ -0,0 +1,81 @@
+package io
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/willpoint/algor/graph"
+)
+
+// weighted is a small weighted graph with an isolated vertex
+func weighted(undirected bool) *graph.Graph {
+	G := graph.NewGraph()
+	if undirected {
+		G = graph.NewUndirectedGraph()
+	}
+	for _, l := range []graph.Label{"a", "b", "c", "d", "e"} {
+		G.AddVertex(l)
+	}
+	G.AddEdge("a", "b", 4)
+	G.AddEdge("b", "c", 1)
+	G.AddEdge("c", "a", -2)
+	G.AddEdge("a", "d", 7)
+	return G
+}
+
+// sameGraph checks that G and H have the same vertices and weighted edges
+func sameGraph(t *testing.T, G, H *graph.Graph) {
+	if G.Undirected != H.Undirected {
+		t.Errorf("expected undirected to be %v, got %v", G.Undirected, H.Undirected)
+	}
+	if G.VNum != H.VNum || G.ENum != H.ENum {
+		t.Fatalf("expected %d vertices and %d edges, got %d and %d", G.VNum, G.ENum, H.VNum, H.ENum)
+	}
+	for l := range G.V {
+		if _, ok := H.V[l]; !ok {
+			t.Errorf("expected vertex %s", l)
+		}
+	}
+	for e, w := range G.E {
+		if hw, ok := H.Weight(e.U.Label, e.V.Label); !ok || hw != w {
+			t.Errorf("expected edge %s of weight %d, got %d", e, w, hw)
+		}
+	}
+}
+
+func TestEdgeList(t *testing.T) {
+	for _, undirected := range []bool{false, true} {
+		G := weighted(undirected)
+		var b bytes.Buffer
+		if err := WriteEdgeList(&b, G); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		H := graph.NewGraph()
+		H.Undirected = undirected
+		if err := ReadEdgeList(&b, H); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		sameGraph(t, G, H)
+	}
+	src := "# deps\nx y 3\n\n y\tz  # tabs\nx y 5\nw\n"
+	G := graph.NewGraph()
+	if err := ReadEdgeList(strings.NewReader(src), G); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	H := graph.NewGraph()
+	for _, l := range []graph.Label{"w", "x", "y", "z"} {
+		H.AddVertex(l)
+	}
+	H.AddEdge("x", "y", 5)
+	H.AddEdge("y", "z", 1)
+	sameGraph(t, H, G)
+	for _, src := range []string{"a b c d\n", "a b heavy\n"} {
+		if err := ReadEdgeList(strings.NewReader(src), graph.NewGraph()); err == nil {
+			t.Errorf("expected an error reading %q", src)
+		}
+	}
+	if err := WriteEdgeList(&bytes.Buffer{}, graph.BuildGraph([][2]string{{"a b", "c"}})); err != ErrInvalidLabel {
+		t.Errorf("expected %v, got %v", ErrInvalidLabel, err)
+	}
+}
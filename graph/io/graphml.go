@@ -0,0 +1,179 @@
+package io
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/willpoint/algor/graph"
+)
+
+var (
+	// ErrNoGraph occurs when a document holds no graph
+	ErrNoGraph = errors.New("no graph found")
+)
+
+// graphmlKey is a <key> declaring a data attribute
+type graphmlKey struct {
+	ID      string `xml:"id,attr"`
+	For     string `xml:"for,attr"`
+	Name    string `xml:"attr.name,attr"`
+	Default string `xml:"default"`
+}
+
+// graphmlEdge is an <edge> with its <data> children
+type graphmlEdge struct {
+	Source string `xml:"source,attr"`
+	Target string `xml:"target,attr"`
+	Data   []struct {
+		Key   string `xml:"key,attr"`
+		Value string `xml:",chardata"`
+	} `xml:"data"`
+}
+
+// attr returns the value of the attribute named name
+func attr(se xml.StartElement, name string) (string, bool) {
+	for _, a := range se.Attr {
+		if a.Name.Local == name {
+			return a.Value, true
+		}
+	}
+	return "", false
+}
+
+// ReadGraphML reads the first graph of a GraphML document from r, it
+// is directed unless the edgedefault of the graph is undirected.
+// Nodes of nested graphs are added to it as well, while any graph
+// after it is left unread. The weight of an edge is the integer value
+// of its data for the edge key named weight, or the default of that
+// key, or 1. A node or an edge end with an empty or missing label is
+// an error
+func ReadGraphML(r io.Reader) (*graph.Graph, error) {
+	d := xml.NewDecoder(r)
+	var G *graph.Graph
+	weightKey, weightDefault := "", 1
+	// depth counts the open elements, graphDepth is the depth of the
+	// first graph, whose end is the end of what is read
+	var depth, graphDepth int
+	for {
+		t, err := d.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if ee, ok := t.(xml.EndElement); ok {
+			if G != nil && depth == graphDepth && ee.Name.Local == "graph" {
+				break
+			}
+			depth--
+			continue
+		}
+		se, ok := t.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		switch se.Name.Local {
+		case "key":
+			var k graphmlKey
+			if err := d.DecodeElement(&k, &se); err != nil {
+				return nil, err
+			}
+			if !strings.EqualFold(k.Name, "weight") || (k.For != "edge" && k.For != "all") {
+				continue
+			}
+			weightKey = k.ID
+			if k.Default = strings.TrimSpace(k.Default); k.Default != "" {
+				if weightDefault, err = strconv.Atoi(k.Default); err != nil {
+					return nil, fmt.Errorf("graphml: default weight %q is not an integer", k.Default)
+				}
+			}
+		case "graph":
+			depth++
+			if G != nil {
+				continue
+			}
+			graphDepth = depth
+			if ed, _ := attr(se, "edgedefault"); ed == "undirected" {
+				G = graph.NewUndirectedGraph()
+			} else {
+				G = graph.NewGraph()
+			}
+		case "node":
+			if G == nil {
+				return nil, fmt.Errorf("graphml: node outside of a graph")
+			}
+			id, ok := attr(se, "id")
+			if !ok || id == "" {
+				return nil, fmt.Errorf("graphml: missing label in node")
+			}
+			G.AddVertex(graph.Label(id))
+			depth++
+		case "edge":
+			if G == nil {
+				return nil, fmt.Errorf("graphml: edge outside of a graph")
+			}
+			var e graphmlEdge
+			if err := d.DecodeElement(&e, &se); err != nil {
+				return nil, err
+			}
+			if e.Source == "" || e.Target == "" {
+				return nil, fmt.Errorf("graphml: missing label in edge (%q, %q)", e.Source, e.Target)
+			}
+			w := weightDefault
+			for _, data := range e.Data {
+				if weightKey == "" || data.Key != weightKey {
+					continue
+				}
+				v := strings.TrimSpace(data.Value)
+				if w, err = strconv.Atoi(v); err != nil {
+					return nil, fmt.Errorf("graphml: weight %q of edge (%s, %s) is not an integer", v, e.Source, e.Target)
+				}
+			}
+			addEdge(G, graph.Label(e.Source), graph.Label(e.Target), w)
+		default:
+			depth++
+		}
+	}
+	if G == nil {
+		return nil, ErrNoGraph
+	}
+	return G, nil
+}
+
+// escape returns s escaped for use as XML text or attribute value
+func escape(s string) string {
+	var b bytes.Buffer
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+// WriteGraphML writes G to w as a GraphML document holding
+// a single graph, with the weight of each edge as its data
+// for an integer edge key named weight
+func WriteGraphML(w io.Writer, G *graph.Graph) error {
+	b := bufio.NewWriter(w)
+	edgedefault := "directed"
+	if G.Undirected {
+		edgedefault = "undirected"
+	}
+	b.WriteString(xml.Header)
+	b.WriteString(`<graphml xmlns="http://graphml.graphdrawing.org/xmlns">` + "\n")
+	b.WriteString(`  <key id="weight" for="edge" attr.name="weight" attr.type="int"><default>1</default></key>` + "\n")
+	fmt.Fprintf(b, "  <graph id=\"G\" edgedefault=\"%s\">\n", edgedefault)
+	for _, l := range labels(G) {
+		fmt.Fprintf(b, "    <node id=\"%s\"/>\n", escape(string(l)))
+	}
+	for _, e := range edges(G) {
+		fmt.Fprintf(b, "    <edge source=\"%s\" target=\"%s\">", escape(string(e.U.Label)), escape(string(e.V.Label)))
+		fmt.Fprintf(b, "<data key=\"weight\">%d</data></edge>\n", G.E[e])
+	}
+	b.WriteString("  </graph>\n</graphml>\n")
+	return b.Flush()
+}
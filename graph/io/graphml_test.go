@@ -0,0 +1,82 @@
+package io
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/willpoint/algor/graph"
+)
+
+func TestGraphML(t *testing.T) {
+	for _, undirected := range []bool{false, true} {
+		G := weighted(undirected)
+		G.AddVertex(`<"&">`)
+		var b bytes.Buffer
+		if err := WriteGraphML(&b, G); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		H, err := ReadGraphML(&b)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		sameGraph(t, G, H)
+	}
+	src := `<?xml version="1.0"?>
+<graphml xmlns="http://graphml.graphdrawing.org/xmlns">
+  <key id="d0" for="node" attr.name="color" attr.type="string"/>
+  <key id="d1" for="edge" attr.name="weight" attr.type="int"><default>2</default></key>
+  <graph id="G" edgedefault="undirected">
+    <node id="n0"><data key="d0">green</data></node>
+    <node id="n1"/>
+    <edge source="n0" target="n1"><data key="d1">5</data></edge>
+    <edge source="n1" target="n2"/>
+  </graph>
+</graphml>`
+	G, err := ReadGraphML(strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	H := graph.NewUndirectedGraph()
+	for _, l := range []graph.Label{"n0", "n1", "n2"} {
+		H.AddVertex(l)
+	}
+	H.AddEdge("n1", "n0", 5)
+	H.AddEdge("n2", "n1", 2)
+	sameGraph(t, H, G)
+	if _, err := ReadGraphML(strings.NewReader(`<graphml/>`)); err != ErrNoGraph {
+		t.Errorf("expected %v, got %v", ErrNoGraph, err)
+	}
+}
+
+func TestGraphMLMissingLabel(t *testing.T) {
+	for _, src := range []string{
+		`<graphml><graph><node/></graph></graphml>`,
+		`<graphml><graph><node id=""/></graph></graphml>`,
+		`<graphml><graph><edge target="b"/></graph></graphml>`,
+		`<graphml><graph><edge source="a" target=""/></graph></graphml>`,
+	} {
+		if _, err := ReadGraphML(strings.NewReader(src)); err == nil {
+			t.Errorf("expected an error reading %s", src)
+		}
+	}
+}
+
+func TestGraphMLFirstGraph(t *testing.T) {
+	src := `<graphml>
+  <graph id="a" edgedefault="directed">
+    <node id="x"><graph id="nested"><node id="w"/></graph></node>
+  </graph>
+  <graph id="b" edgedefault="directed">
+    <node id="y"/><node id="z"/>
+    <edge source="y" target="z"/>
+  </graph>
+</graphml>`
+	G, err := ReadGraphML(strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if G.VNum != 2 || G.ENum != 0 || G.V["x"] == nil || G.V["w"] == nil {
+		t.Errorf("expected the vertices x and w only, got %d vertices and %d edges", G.VNum, G.ENum)
+	}
+}
@@ -0,0 +1,220 @@
+package io
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/willpoint/algor/graph"
+)
+
+// jsonEdge is an edge of the JSON schema read by ReadJSON
+type jsonEdge struct {
+	U      string `json:"u"`
+	V      string `json:"v"`
+	Weight *int   `json:"weight,omitempty"`
+}
+
+// jsonPair is an element of the slices given to graph.BuildGraph
+// (["u", "v"]) and graph.BuildWeightedGraph ({"pair": ["u", "v"], "weight": w})
+type jsonPair struct {
+	Pair   [2]string
+	Weight int
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface
+func (p *jsonPair) UnmarshalJSON(b []byte) error {
+	p.Weight = 1
+	if err := json.Unmarshal(b, &p.Pair); err == nil {
+		return nil
+	}
+	var w struct {
+		Pair   [2]string `json:"pair"`
+		Weight *int      `json:"weight"`
+	}
+	if err := json.Unmarshal(b, &w); err != nil {
+		return err
+	}
+	p.Pair = w.Pair
+	if w.Weight != nil {
+		p.Weight = *w.Weight
+	}
+	return nil
+}
+
+// ReadJSON reads a graph in the JSON schema below from r
+//
+//	{
+//		"directed": true,
+//		"vertices": ["a", "b", "c"],
+//		"edges": [
+//			{"u": "a", "v": "b", "weight": 4}
+//		]
+//	}
+//
+// where a vertex need only be listed in vertices if it has no edge,
+// the weight of an edge is 1 if omitted, and other members are
+// ignored. A vertex or an edge end with an empty or missing label is
+// an error. It decodes one vertex or edge at a time, and when
+// "directed" comes before "edges", as written by WriteJSON, each edge
+// is added to the graph as it is read and the graph is directed
+// unless "directed" is false.
+// It also reads the arrays of pairs taken by graph.BuildGraph and
+// graph.BuildWeightedGraph, as a directed graph, where a pair whose
+// second label is empty adds a single vertex
+func ReadJSON(r io.Reader) (*graph.Graph, error) {
+	d := json.NewDecoder(bufio.NewReader(r))
+	t, err := d.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch t {
+	case json.Delim('['):
+		G := graph.NewGraph()
+		for d.More() {
+			var p jsonPair
+			if err := d.Decode(&p); err != nil {
+				return nil, err
+			}
+			u, v := graph.Label(p.Pair[0]), graph.Label(p.Pair[1])
+			if u == "" {
+				return nil, fmt.Errorf("json: missing label in pair %q", p.Pair)
+			}
+			if v == "" {
+				G.AddVertex(u)
+				continue
+			}
+			addEdge(G, u, v, p.Weight)
+		}
+		return G, nil
+	case json.Delim('{'):
+		return readJSONObject(d)
+	}
+	return nil, fmt.Errorf("json: expected an object or an array, found %v", t)
+}
+
+// readJSONObject reads the members of the JSON schema object
+func readJSONObject(d *json.Decoder) (*graph.Graph, error) {
+	G := graph.NewGraph()
+	directed := true
+	for d.More() {
+		t, err := d.Token()
+		if err != nil {
+			return nil, err
+		}
+		switch t {
+		case "directed":
+			if err := d.Decode(&directed); err != nil {
+				return nil, err
+			}
+			if !directed && !G.Undirected {
+				G = undirected(G)
+			}
+		case "vertices":
+			if err := readJSONArray(d, func() error {
+				var l string
+				if err := d.Decode(&l); err != nil {
+					return err
+				}
+				if l == "" {
+					return fmt.Errorf("json: missing label in vertices")
+				}
+				G.AddVertex(graph.Label(l))
+				return nil
+			}); err != nil {
+				return nil, err
+			}
+		case "edges":
+			if err := readJSONArray(d, func() error {
+				var e jsonEdge
+				if err := d.Decode(&e); err != nil {
+					return err
+				}
+				if e.U == "" || e.V == "" {
+					return fmt.Errorf("json: missing label in edge (%q, %q)", e.U, e.V)
+				}
+				w := 1
+				if e.Weight != nil {
+					w = *e.Weight
+				}
+				addEdge(G, graph.Label(e.U), graph.Label(e.V), w)
+				return nil
+			}); err != nil {
+				return nil, err
+			}
+		default:
+			var skip json.RawMessage
+			if err := d.Decode(&skip); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if _, err := d.Token(); err != nil {
+		return nil, err
+	}
+	return G, nil
+}
+
+// readJSONArray calls fn to decode each element of an array
+func readJSONArray(d *json.Decoder, fn func() error) error {
+	t, err := d.Token()
+	if err != nil {
+		return err
+	}
+	if t != json.Delim('[') {
+		return fmt.Errorf("json: expected an array, found %v", t)
+	}
+	for d.More() {
+		if err := fn(); err != nil {
+			return err
+		}
+	}
+	_, err = d.Token()
+	return err
+}
+
+// undirected returns an undirected copy of the directed graph G,
+// the edges (u, v) and (v, u) of G becoming the same edge
+func undirected(G *graph.Graph) *graph.Graph {
+	H := graph.NewUndirectedGraph()
+	for _, l := range labels(G) {
+		H.AddVertex(l)
+	}
+	for _, e := range edges(G) {
+		addEdge(H, e.U.Label, e.V.Label, G.E[e])
+	}
+	return H
+}
+
+// WriteJSON writes G to w in the JSON schema, one
+// vertex or edge at a time, all edges with their weight
+func WriteJSON(w io.Writer, G *graph.Graph) error {
+	b := bufio.NewWriter(w)
+	fmt.Fprintf(b, "{\n\t\"directed\": %v,\n\t\"vertices\": [", !G.Undirected)
+	for i, l := range labels(G) {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		s, err := json.Marshal(string(l))
+		if err != nil {
+			return err
+		}
+		b.Write(s)
+	}
+	b.WriteString("],\n\t\"edges\": [")
+	for i, e := range edges(G) {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		wt := G.E[e]
+		s, err := json.Marshal(jsonEdge{U: string(e.U.Label), V: string(e.V.Label), Weight: &wt})
+		if err != nil {
+			return err
+		}
+		b.WriteString("\n\t\t")
+		b.Write(s)
+	}
+	b.WriteString("\n\t]\n}\n")
+	return b.Flush()
+}
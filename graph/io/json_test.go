@@ -0,0 +1,66 @@
+package io
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestJSON(t *testing.T) {
+	for _, undirected := range []bool{false, true} {
+		G := weighted(undirected)
+		var b bytes.Buffer
+		if err := WriteJSON(&b, G); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		H, err := ReadJSON(&b)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		sameGraph(t, G, H)
+	}
+	// directed given last
+	G, err := ReadJSON(strings.NewReader(`{"edges": [{"u": "a", "v": "b"}, {"u": "b", "v": "a", "weight": 3}], "name": "x", "directed": false}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if w, _ := G.Weight("a", "b"); !G.Undirected || G.ENum != 1 || w != 3 {
+		t.Errorf("expected a single undirected edge of weight 3, got %d edges and weight %d", G.ENum, w)
+	}
+}
+
+func TestJSONPairs(t *testing.T) {
+	G, err := ReadJSON(strings.NewReader(`[["a", "b"], ["b", "c"], ["d", ""]]`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if G.VNum != 4 || G.ENum != 2 {
+		t.Errorf("expected 4 vertices and 2 edges, got %d and %d", G.VNum, G.ENum)
+	}
+	G, err = ReadJSON(strings.NewReader(`[{"pair": ["s", "t"], "weight": 10}, {"pair": ["t", "x"]}]`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if w, _ := G.Weight("s", "t"); w != 10 {
+		t.Errorf("expected weight 10, got %d", w)
+	}
+	if w, _ := G.Weight("t", "x"); w != 1 {
+		t.Errorf("expected weight 1, got %d", w)
+	}
+	if _, err := ReadJSON(strings.NewReader(`"graph"`)); err == nil {
+		t.Errorf("expected an error reading a string")
+	}
+}
+
+func TestJSONMissingLabel(t *testing.T) {
+	for _, src := range []string{
+		`{"edges": [{"v": "b"}]}`,
+		`{"edges": [{"u": "a", "v": ""}]}`,
+		`{"vertices": ["a", ""]}`,
+		`[["", "b"]]`,
+	} {
+		if _, err := ReadJSON(strings.NewReader(src)); err == nil {
+			t.Errorf("expected an error reading %s", src)
+		}
+	}
+}
@@ -0,0 +1,286 @@
+package graph
+
+import (
+	"errors"
+
+	"github.com/willpoint/algor/heap"
+)
+
+var (
+	// ErrUnbalanced occurs when the supplies and
+	// demands of a flow network do not add up to 0
+	ErrUnbalanced = errors.New("supplies and demands do not balance")
+	// ErrInfeasible occurs when the capacities of a flow network
+	// cannot carry the supplies to meet the demands
+	ErrInfeasible = errors.New("supplies cannot meet the demands")
+)
+
+// CostFlow is a minimum-cost flow in a flow network G = (V, E)
+// where the weight of each edge (u, v) ∈ E is its capacity and
+// a(u, v) the cost of sending a unit of flow along it
+// Value is the total flow shipped, Cost the sum of a(u, v) f(u, v)
+// over the edges and F gives the flow f(u, v) on each edge of G
+type CostFlow struct {
+	Value int
+	Cost  int
+	F     map[Edge]int
+}
+
+// costArc is an arc of the residual network of a min-cost flow,
+// the arc of an edge being followed by its reverse in arcs, so that
+// the reverse of arc i is arc i^1, with no capacity and negated cost
+type costArc struct {
+	u, v Label
+	cap  int
+	cost int
+	flow int
+}
+
+// costNetwork is the residual network of a flow network with costs
+// out holds the indices of the arcs leaving each vertex and pi the
+// potential of each vertex, which keeps the reduced cost
+// a(u, v) + pi(u) - pi(v) of each residual arc nonnegative
+type costNetwork struct {
+	G    *Graph
+	arcs []costArc
+	edge []Edge
+	out  map[Label][]int
+	pi   map[Label]int
+}
+
+func newCostNetwork(G *Graph, cost map[[2]Label]int) (*costNetwork, error) {
+	if G.Undirected {
+		return nil, ErrUndirected
+	}
+	n := &costNetwork{
+		G:   G,
+		out: make(map[Label][]int, len(G.V)),
+		pi:  make(map[Label]int, len(G.V)),
+	}
+	edges := make([]Edge, 0, len(G.E))
+	for e, w := range G.E {
+		if w < 0 {
+			return nil, ErrNegativeCapacity
+		}
+		edges = append(edges, e)
+	}
+	sortEdges(edges)
+	for _, e := range edges {
+		u, v := e.U.Label, e.V.Label
+		a := cost[[2]Label{u, v}]
+		n.out[u] = append(n.out[u], len(n.arcs))
+		n.arcs = append(n.arcs, costArc{u: u, v: v, cap: G.E[e], cost: a})
+		n.out[v] = append(n.out[v], len(n.arcs))
+		n.arcs = append(n.arcs, costArc{u: v, v: u, cost: -a})
+		n.edge = append(n.edge, e)
+	}
+	return n, nil
+}
+
+func (n *costNetwork) residual(i int) int {
+	return n.arcs[i].cap - n.arcs[i].flow
+}
+
+// potentials sets pi to the weights of shortest paths over the arcs
+// with residual capacity from a virtual source joined to every vertex
+// at no cost, found by Bellman-Ford as costs may be negative
+// It returns a *NegativeCycleError if the arcs have a negative-cost
+// cycle, along which flow could be sent round to lower the cost
+// without bound
+func (n *costNetwork) potentials() error {
+	for l := range n.G.V {
+		n.pi[l] = 0
+	}
+	pred := make(map[Label]Label, len(n.G.V))
+	for i := 0; i <= len(n.G.V); i++ {
+		var last Label
+		changed := false
+		for j, a := range n.arcs {
+			if n.residual(j) > 0 && n.pi[a.u]+a.cost < n.pi[a.v] {
+				n.pi[a.v] = n.pi[a.u] + a.cost
+				pred[a.v] = a.u
+				last, changed = a.v, true
+			}
+		}
+		if !changed {
+			return nil
+		}
+		if i == len(n.G.V) {
+			p := &Paths{Predecessor: pred}
+			return &NegativeCycleError{Cycle: p.negativeCycle(last, len(n.G.V))}
+		}
+	}
+	return nil
+}
+
+// shortest runs Dijkstra's algorithm on the reduced costs from all
+// vertices with a positive excess at once, and returns the arc into
+// each vertex reached on its shortest path, and the deficit vertex,
+// with a negative excess, closest to the sources, or false if none
+// is reached. The potentials are then raised by the distances found,
+// which keeps the reduced costs nonnegative on the arcs of the
+// shortest paths and on their reverses, which augmenting may add
+func (n *costNetwork) shortest(excess map[Label]int) (map[Label]int, Label, bool) {
+	dist := make(map[Label]int, len(n.G.V))
+	via := make(map[Label]int, len(n.G.V))
+	Q := heap.NewIndexedMinPQ()
+	for _, l := range n.G.labels() {
+		if excess[l] > 0 {
+			dist[l] = 0
+			Q.Insert(l, 0)
+		}
+	}
+	var sink Label
+	found := false
+	for !Q.Empty() {
+		min, d, _ := Q.ExtractMin()
+		u := min.(Label)
+		if excess[u] < 0 && !found {
+			sink, found = u, true
+		}
+		for _, i := range n.out[u] {
+			a := n.arcs[i]
+			if n.residual(i) <= 0 {
+				continue
+			}
+			dv := d + a.cost + n.pi[u] - n.pi[a.v]
+			if old, ok := dist[a.v]; !ok || dv < old {
+				dist[a.v] = dv
+				via[a.v] = i
+				enqueue(Q, a.v, dv)
+			}
+		}
+	}
+	for l, d := range dist {
+		n.pi[l] += d
+	}
+	return via, sink, found
+}
+
+// ship sends flow by successive shortest paths from the vertices with
+// a positive excess to those with a negative one, until there is no
+// path left between them. Each path carries as much flow as its
+// residual capacities, its source excess and its sink deficit allow
+func (n *costNetwork) ship(excess map[Label]int) error {
+	if err := n.potentials(); err != nil {
+		return err
+	}
+	for {
+		via, t, ok := n.shortest(excess)
+		if !ok {
+			return nil
+		}
+		d := -excess[t]
+		s := t
+		for excess[s] <= 0 {
+			i := via[s]
+			if r := n.residual(i); r < d {
+				d = r
+			}
+			s = n.arcs[i].u
+		}
+		if excess[s] < d {
+			d = excess[s]
+		}
+		for v := t; v != s; {
+			i := via[v]
+			n.arcs[i].flow += d
+			n.arcs[i^1].flow -= d
+			v = n.arcs[i].u
+		}
+		excess[s] -= d
+		excess[t] += d
+	}
+}
+
+// result collects the flow on each edge of G and its cost
+func (n *costNetwork) result() *CostFlow {
+	fl := &CostFlow{F: make(map[Edge]int, len(n.edge))}
+	for i, e := range n.edge {
+		a := n.arcs[2*i]
+		fl.F[e] = a.flow
+		fl.Cost += a.flow * a.cost
+	}
+	return fl
+}
+
+// MinCostFlow finds a flow of least cost in a directed flow network G,
+// whose edge weights are capacities and whose costs per unit of flow
+// are given by cost for the pairs of labels (u, v) of its edges, 0 if
+// missing, that meets the supply of every vertex: a vertex v with
+// supply b(v) > 0 sends out b(v) more units than it takes in, and one
+// with b(v) < 0 takes in -b(v) more than it sends out
+// It uses successive shortest paths, each augmenting the flow along a
+// least-cost path in the residual network from a vertex with supply
+// left to one with demand left, found by Dijkstra's algorithm on costs
+// reduced by vertex potentials first computed by Bellman-Ford
+// It returns ErrUnbalanced if the supplies do not add up to 0,
+// ErrInfeasible if they cannot be met, and a *NegativeCycleError if
+// G has a cycle of negative cost
+// This is achieved in an order 0(VE + B(V + E) lg V)-time for a total
+// supply of B
+func MinCostFlow(G *Graph, cost map[[2]Label]int, supply map[Label]int) (*CostFlow, error) {
+	var sum, total int
+	excess := make(map[Label]int, len(supply))
+	for l, b := range supply {
+		if _, ok := G.V[l]; !ok {
+			return nil, ErrVertexNotFound
+		}
+		sum += b
+		if b > 0 {
+			total += b
+		}
+		excess[l] = b
+	}
+	if sum != 0 {
+		return nil, ErrUnbalanced
+	}
+	n, err := newCostNetwork(G, cost)
+	if err != nil {
+		return nil, err
+	}
+	if err := n.ship(excess); err != nil {
+		return nil, err
+	}
+	for _, b := range excess {
+		if b != 0 {
+			return nil, ErrInfeasible
+		}
+	}
+	fl := n.result()
+	fl.Value = total
+	return fl, nil
+}
+
+// MinCostMaxFlow finds a maximum flow from s to t of least cost in a
+// directed flow network G, with costs given as for MinCostFlow, by
+// giving s a supply and t a demand as large as the capacity leaving
+// s and shipping flow until no augmenting path from s to t is left
+// It returns a *NegativeCycleError if G has a cycle of negative cost
+// This is achieved in an order 0(VE + |f|(V + E) lg V)-time
+func MinCostMaxFlow(G *Graph, cost map[[2]Label]int, s, t Label) (*CostFlow, error) {
+	if _, ok := G.V[s]; !ok {
+		return nil, ErrVertexNotFound
+	}
+	if _, ok := G.V[t]; !ok {
+		return nil, ErrVertexNotFound
+	}
+	if s == t {
+		return nil, ErrSourceIsSink
+	}
+	n, err := newCostNetwork(G, cost)
+	if err != nil {
+		return nil, err
+	}
+	var bound int
+	for _, i := range n.out[s] {
+		bound += n.arcs[i].cap
+	}
+	excess := map[Label]int{s: bound, t: -bound}
+	if err := n.ship(excess); err != nil {
+		return nil, err
+	}
+	fl := n.result()
+	fl.Value = bound - excess[s]
+	return fl, nil
+}
@@ -0,0 +1,96 @@
+package graph
+
+import "testing"
+
+func TestMinCostMaxFlow(t *testing.T) {
+	G := BuildWeightedGraph([]weightedPair{
+		{Pair: [2]string{"s", "a"}, Weight: 3},
+		{Pair: [2]string{"s", "b"}, Weight: 2},
+		{Pair: [2]string{"a", "b"}, Weight: 2},
+		{Pair: [2]string{"a", "t"}, Weight: 2},
+		{Pair: [2]string{"b", "t"}, Weight: 3},
+	})
+	cost := map[[2]Label]int{
+		{"s", "a"}: 1, {"s", "b"}: 4, {"a", "b"}: 1, {"a", "t"}: 5, {"b", "t"}: 1,
+	}
+	fl, err := MinCostMaxFlow(G, cost, "s", "t")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fl.Value != 5 || fl.Cost != 25 {
+		t.Errorf("expected a flow of 5 costing 25, got %d costing %d", fl.Value, fl.Cost)
+	}
+	expected := map[[2]Label]int{
+		{"s", "a"}: 3, {"s", "b"}: 2, {"a", "b"}: 1, {"a", "t"}: 2, {"b", "t"}: 3,
+	}
+	for e, f := range fl.F {
+		if x := expected[[2]Label{e.U.Label, e.V.Label}]; f != x {
+			t.Errorf("expected flow %d on (%s, %s), got %d", x, e.U.Label, e.V.Label, f)
+		}
+	}
+
+	// the value matches that of a maximum flow with no costs
+	N := BuildWeightedGraph(clrsFlow)
+	fl, err = MinCostMaxFlow(N, nil, "s", "t")
+	if err != nil || fl.Value != 23 || fl.Cost != 0 {
+		t.Errorf("expected a flow of 23 costing 0, got %v: %v", fl, err)
+	}
+	if _, err := MinCostMaxFlow(N, nil, "s", "s"); err != ErrSourceIsSink {
+		t.Errorf("expected %v, got %v", ErrSourceIsSink, err)
+	}
+	if _, err := MinCostMaxFlow(BuildUndirectedGraph(nil), nil, "s", "t"); err != ErrVertexNotFound {
+		t.Errorf("expected %v, got %v", ErrVertexNotFound, err)
+	}
+}
+
+func TestMinCostFlow(t *testing.T) {
+	G := BuildWeightedGraph([]weightedPair{
+		{Pair: [2]string{"p1", "c1"}, Weight: 5},
+		{Pair: [2]string{"p1", "c2"}, Weight: 5},
+		{Pair: [2]string{"p2", "c1"}, Weight: 5},
+		{Pair: [2]string{"p2", "c2"}, Weight: 5},
+	})
+	cost := map[[2]Label]int{
+		{"p1", "c1"}: 1, {"p1", "c2"}: 4, {"p2", "c1"}: 2, {"p2", "c2"}: 3,
+	}
+	supply := map[Label]int{"p1": 3, "p2": 2, "c1": -2, "c2": -3}
+	fl, err := MinCostFlow(G, cost, supply)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fl.Value != 5 || fl.Cost != 12 {
+		t.Errorf("expected a flow of 5 costing 12, got %d costing %d", fl.Value, fl.Cost)
+	}
+	expected := map[[2]Label]int{
+		{"p1", "c1"}: 2, {"p1", "c2"}: 1, {"p2", "c1"}: 0, {"p2", "c2"}: 2,
+	}
+	for e, f := range fl.F {
+		if x := expected[[2]Label{e.U.Label, e.V.Label}]; f != x {
+			t.Errorf("expected flow %d on (%s, %s), got %d", x, e.U.Label, e.V.Label, f)
+		}
+	}
+
+	supply["c2"] = -2
+	if _, err := MinCostFlow(G, cost, supply); err != ErrUnbalanced {
+		t.Errorf("expected %v, got %v", ErrUnbalanced, err)
+	}
+	supply["c2"] = -3
+	G.SetWeight("p1", "c2", 0)
+	G.SetWeight("p2", "c2", 2)
+	if _, err := MinCostFlow(G, cost, supply); err != ErrInfeasible {
+		t.Errorf("expected %v, got %v", ErrInfeasible, err)
+	}
+
+	C := BuildWeightedGraph([]weightedPair{
+		{Pair: [2]string{"a", "b"}, Weight: 1},
+		{Pair: [2]string{"b", "c"}, Weight: 1},
+		{Pair: [2]string{"c", "a"}, Weight: 1},
+	})
+	_, err = MinCostFlow(C, map[[2]Label]int{{"a", "b"}: 1, {"b", "c"}: -3}, nil)
+	if _, ok := err.(*NegativeCycleError); !ok {
+		t.Errorf("expected a negative cycle error, got %v", err)
+	}
+	if _, err := MinCostFlow(BuildUndirectedGraph([][2]string{{"a", "b"}}), nil, nil); err != ErrUndirected {
+		t.Errorf("expected %v, got %v", ErrUndirected, err)
+	}
+}
@@ -0,0 +1,105 @@
+package graph
+
+import (
+	"sort"
+
+	"github.com/willpoint/algor/disjointset"
+	"github.com/willpoint/algor/heap"
+)
+
+// A minimum spanning tree of a connected graph G = (V, E) is an
+// acyclic subset T ⊆ E that connects all of the vertices and whose
+// total weight w(T) is minimized. For a graph that is not connected
+// the result is a minimum spanning forest with one tree for each of
+// its connected components.
+// Edges are treated as undirected, such that (u, v) and (v, u) both
+// connect u and v; the returned edges keep the direction in which
+// they are stored in G.E
+
+// sortedEdges returns the edges of G in nondecreasing order
+// of weight, breaking ties by the labels of their vertices
+func sortedEdges(G *Graph) []Edge {
+	edges := make([]Edge, 0, len(G.E))
+	for e := range G.E {
+		edges = append(edges, e)
+	}
+	sort.Slice(edges, func(i, j int) bool {
+		a, b := edges[i], edges[j]
+		if G.E[a] != G.E[b] {
+			return G.E[a] < G.E[b]
+		}
+		if a.U.Label != b.U.Label {
+			return a.U.Label < b.U.Label
+		}
+		return a.V.Label < b.V.Label
+	})
+	return edges
+}
+
+// Kruskal finds a minimum spanning forest of G by growing a forest
+// of trees, adding at each step the least-weight edge that connects
+// two distinct trees. A disjoint-set forest keeps track of the tree
+// each vertex belongs to
+// It returns the edges of the forest and their total weight
+// This is achieved in an order 0(E log V)-time
+func Kruskal(G *Graph) ([]Edge, int) {
+	f := disjointset.NewForest()
+	for l := range G.V {
+		f.MakeSet(l)
+	}
+	var A []Edge
+	var weight int
+	for _, e := range sortedEdges(G) {
+		if f.Union(e.U.Label, e.V.Label) {
+			A = append(A, e)
+			weight += G.E[e]
+		}
+	}
+	return A, weight
+}
+
+// Prim finds a minimum spanning forest of G by growing a single tree
+// from a root, adding at each step the least-weight edge connecting
+// the tree to a vertex not yet in it. The vertices not in the tree are
+// held in an indexed min-priority queue keyed on the weight of the
+// least edge connecting them to the tree, while π(v) names the vertex
+// of the tree at the other end of that edge.
+// When the queue yields a vertex with no such edge, it becomes the
+// root of a new tree of the forest
+// It returns the edges of the forest and their total weight
+// This is achieved in an order 0(E lg V)-time
+func Prim(G *Graph) ([]Edge, int) {
+	adj := make(map[*Vertex][]Edge, len(G.V))
+	for e := range G.E {
+		if e.U == e.V {
+			continue
+		}
+		adj[e.U] = append(adj[e.U], e)
+		adj[e.V] = append(adj[e.V], e)
+	}
+	Q := heap.NewIndexedMinPQ()
+	for _, l := range G.labels() {
+		Q.Insert(l, infinity)
+	}
+	best := make(map[*Vertex]Edge, len(G.V))
+	var A []Edge
+	var weight int
+	for !Q.Empty() {
+		min, key, _ := Q.ExtractMin()
+		u := G.V[min.(Label)]
+		if e, ok := best[u]; ok {
+			A = append(A, e)
+			weight += key
+		}
+		for _, e := range adj[u] {
+			v := e.V
+			if v == u {
+				v = e.U
+			}
+			if Q.DecreaseKey(v.Label, G.E[e]) {
+				best[v] = e
+			}
+		}
+	}
+	return A, weight
+}
@@ -0,0 +1,60 @@
+package graph
+
+import "testing"
+
+// clrsMST is the graph of figure 23.1 in CLRS
+// with each undirected edge stored once
+var clrsMST = []weightedPair{
+	{Pair: [2]string{"a", "b"}, Weight: 4},
+	{Pair: [2]string{"a", "h"}, Weight: 8},
+	{Pair: [2]string{"b", "c"}, Weight: 8},
+	{Pair: [2]string{"b", "h"}, Weight: 11},
+	{Pair: [2]string{"c", "d"}, Weight: 7},
+	{Pair: [2]string{"c", "f"}, Weight: 4},
+	{Pair: [2]string{"c", "i"}, Weight: 2},
+	{Pair: [2]string{"d", "e"}, Weight: 9},
+	{Pair: [2]string{"d", "f"}, Weight: 14},
+	{Pair: [2]string{"e", "f"}, Weight: 10},
+	{Pair: [2]string{"g", "f"}, Weight: 2},
+	{Pair: [2]string{"h", "g"}, Weight: 1},
+	{Pair: [2]string{"h", "i"}, Weight: 7},
+	{Pair: [2]string{"i", "g"}, Weight: 6},
+}
+
+func TestMST(t *testing.T) {
+	for name, mst := range map[string]func(*Graph) ([]Edge, int){
+		"kruskal": Kruskal,
+		"prim":    Prim,
+	} {
+		G := BuildWeightedGraph(clrsMST)
+		A, w := mst(G)
+		if len(A) != G.VNum-1 {
+			t.Errorf("%s: expected %d edges, got %d", name, G.VNum-1, len(A))
+		}
+		if w != 37 {
+			t.Errorf("%s: expected total weight %d, got %d", name, 37, w)
+		}
+		t.Logf("%s: %v", name, A)
+	}
+}
+
+func TestMSTForest(t *testing.T) {
+	pairs := append([]weightedPair{
+		{Pair: [2]string{"x", "y"}, Weight: 3},
+		{Pair: [2]string{"y", "z"}, Weight: -1},
+		{Pair: [2]string{"z", "x"}, Weight: 5},
+	}, clrsMST...)
+	for name, mst := range map[string]func(*Graph) ([]Edge, int){
+		"kruskal": Kruskal,
+		"prim":    Prim,
+	} {
+		G := BuildWeightedGraph(pairs)
+		A, w := mst(G)
+		if len(A) != G.VNum-2 {
+			t.Errorf("%s: expected %d edges, got %d", name, G.VNum-2, len(A))
+		}
+		if w != 39 {
+			t.Errorf("%s: expected total weight %d, got %d", name, 39, w)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package graph
+
+import "errors"
+
+var (
+	// ErrEdgeExists occurs when adding an edge (u, v) that is already in E
+	ErrEdgeExists = errors.New("edge already exists")
+	// ErrEdgeNotFound occurs when the pair of labels (u, v)
+	// does not identify any edge in the graph
+	ErrEdgeNotFound = errors.New("edge not found")
+)
+
+// The methods below keep V, E, VNum, ENum and the adjacency list
+// of each vertex consistent with one another, they are to be
+// preferred to modifying the fields of G directly
+
+// AddVertex adds a new vertex labeled l to G
+// and returns ErrVertexExists if l is already in V
+func (G *Graph) AddVertex(l Label) (*Vertex, error) {
+	if _, ok := G.V[l]; ok {
+		return nil, ErrVertexExists
+	}
+	v := NewVertex(l)
+	G.V[l] = v
+	G.VNum++
+	return v, nil
+}
+
+// edge returns the edge (u, v) ∈ E
+func (G *Graph) edge(u, v Label) (Edge, bool) {
+	lu, ok1 := G.V[u]
+	lv, ok2 := G.V[v]
+	if !ok1 || !ok2 {
+		return Edge{}, false
+	}
+	e := G.key(NewEdge(lu, lv))
+	_, ok := G.E[e]
+	return e, ok
+}
+
+// Weight returns the weight of the edge (u, v)
+// and false if (u, v) is not in E
+func (G *Graph) Weight(u, v Label) (int, bool) {
+	e, ok := G.edge(u, v)
+	if !ok {
+		return 0, false
+	}
+	return G.E[e], true
+}
+
+// AddEdge adds the edge (u, v) with weight w to G, both
+// vertices must already be in V or ErrVertexNotFound is returned
+// An edge is added at most once, ErrEdgeExists is returned
+// if (u, v) is already in E, or (v, u) for an undirected graph
+func (G *Graph) AddEdge(u, v Label, w int) error {
+	lu, ok1 := G.V[u]
+	lv, ok2 := G.V[v]
+	if !ok1 || !ok2 {
+		return ErrVertexNotFound
+	}
+	e := G.key(NewEdge(lu, lv))
+	if _, ok := G.E[e]; ok {
+		return ErrEdgeExists
+	}
+	lu.Adj = append(lu.Adj, v)
+	if G.Undirected && u != v {
+		lv.Adj = append(lv.Adj, u)
+	}
+	G.E[e] = w
+	G.ENum++
+	return nil
+}
+
+// SetWeight changes the weight of the edge (u, v) to w
+func (G *Graph) SetWeight(u, v Label, w int) error {
+	e, ok := G.edge(u, v)
+	if !ok {
+		return ErrEdgeNotFound
+	}
+	G.E[e] = w
+	return nil
+}
+
+// RemoveEdge removes the edge (u, v) from G
+func (G *Graph) RemoveEdge(u, v Label) error {
+	e, ok := G.edge(u, v)
+	if !ok {
+		return ErrEdgeNotFound
+	}
+	delete(G.E, e)
+	e.U.Adj = removeLabel(e.U.Adj, e.V.Label)
+	if G.Undirected && e.U != e.V {
+		e.V.Adj = removeLabel(e.V.Adj, e.U.Label)
+	}
+	G.ENum--
+	return nil
+}
+
+// RemoveVertex removes the vertex labeled l from G
+// together with every edge entering or leaving it
+// This is achieved in an order 0(E)-time
+func (G *Graph) RemoveVertex(l Label) error {
+	x, ok := G.V[l]
+	if !ok {
+		return ErrVertexNotFound
+	}
+	for e := range G.E {
+		if e.U != x && e.V != x {
+			continue
+		}
+		if e.V == x && e.U != x {
+			e.U.Adj = removeLabel(e.U.Adj, l)
+		}
+		if G.Undirected && e.U == x && e.V != x {
+			e.V.Adj = removeLabel(e.V.Adj, l)
+		}
+		delete(G.E, e)
+		G.ENum--
+	}
+	delete(G.V, l)
+	G.VNum--
+	return nil
+}
+
+// removeLabel removes the first occurrence of l from adj
+// keeping the order of the remaining labels
+func removeLabel(adj []Label, l Label) []Label {
+	for i, j := range adj {
+		if j == l {
+			return append(adj[:i], adj[i+1:]...)
+		}
+	}
+	return adj
+}
@@ -0,0 +1,74 @@
+package graph
+
+import "testing"
+
+// consistent checks that VNum, ENum and the adjacency lists agree with V and E
+// where each edge of an undirected graph is in the adjacency lists of both ends
+func consistent(t *testing.T, G *Graph) {
+	if G.VNum != len(G.V) {
+		t.Errorf("expected VNum %d to equal |V| %d", G.VNum, len(G.V))
+	}
+	if G.ENum != len(G.E) {
+		t.Errorf("expected ENum %d to equal |E| %d", G.ENum, len(G.E))
+	}
+	var adj int
+	for _, u := range G.V {
+		for _, l := range u.Adj {
+			v, ok := G.V[l]
+			if !ok {
+				t.Fatalf("%s is adjacent to %s which is not in V", l, u.Label)
+			}
+			if _, ok := G.Weight(u.Label, v.Label); !ok {
+				t.Errorf("expected (%s, %s) to be in E", u.Label, l)
+			}
+			adj++
+		}
+	}
+	if arcs := len(G.arcs()); adj != arcs {
+		t.Errorf("expected %d adjacent vertices, got %d", arcs, adj)
+	}
+}
+
+func TestMutate(t *testing.T) {
+	G := BuildGraph([][2]string{{"a", "b"}, {"a", "b"}, {"b", "c"}, {"d", ""}})
+	consistent(t, G)
+	if _, err := G.AddVertex("a"); err != ErrVertexExists {
+		t.Errorf("expected %v, got %v", ErrVertexExists, err)
+	}
+	if err := G.AddEdge("a", "z", 1); err != ErrVertexNotFound {
+		t.Errorf("expected %v, got %v", ErrVertexNotFound, err)
+	}
+	if err := G.AddEdge("a", "b", 1); err != ErrEdgeExists {
+		t.Errorf("expected %v, got %v", ErrEdgeExists, err)
+	}
+	if err := G.AddEdge("c", "a", 3); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := G.AddEdge("d", "b", 1); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := G.SetWeight("c", "a", -2); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if w, ok := G.Weight("c", "a"); !ok || w != -2 {
+		t.Errorf("expected weight of (c, a) to be %d, got %d", -2, w)
+	}
+	consistent(t, G)
+	if err := G.RemoveEdge("a", "c"); err != ErrEdgeNotFound {
+		t.Errorf("expected %v, got %v", ErrEdgeNotFound, err)
+	}
+	if err := G.RemoveEdge("a", "b"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	consistent(t, G)
+	if err := G.RemoveVertex("b"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := G.RemoveVertex("b"); err != ErrVertexNotFound {
+		t.Errorf("expected %v, got %v", ErrVertexNotFound, err)
+	}
+	consistent(t, G)
+	if G.VNum != 3 || G.ENum != 1 {
+		t.Errorf("expected 3 vertices and 1 edge, got %d and %d", G.VNum, G.ENum)
+	}
+}
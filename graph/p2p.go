@@ -0,0 +1,79 @@
+package graph
+
+import (
+	"github.com/willpoint/algor/heap"
+)
+
+// ShortestPath returns a shortest path from src to dst in a graph G
+// with nonnegative edge weights, running Dijkstra's algorithm from
+// src only until dst is settled, which is AStar with a heuristic of 0
+// It returns ErrNoPath if dst is not reachable from src
+func ShortestPath(G *Graph, src, dst Label) (*Path, error) {
+	return AStar(G, src, dst, func(Label) int { return 0 })
+}
+
+// BidirectionalDijkstra returns a shortest path from src to dst in a
+// graph G with nonnegative edge weights by running Dijkstra's algorithm
+// forward from src in G and backward from dst in the transpose of G,
+// always expanding the side whose least estimate is smaller
+// Each edge relaxed towards a vertex the other search has reached
+// is a candidate meeting point, and the search stops once the least
+// estimates of the two sides add up to at least the weight of the
+// best path through a meeting point, as no path left can be lighter
+// On sparse graphs the two searches settle far fewer vertices between
+// them than a single search from src that reaches dst
+// It returns ErrNoPath if dst is not reachable from src
+func BidirectionalDijkstra(G *Graph, src, dst Label) (*Path, error) {
+	if _, ok := G.V[src]; !ok {
+		return nil, ErrVertexNotFound
+	}
+	if _, ok := G.V[dst]; !ok {
+		return nil, ErrVertexNotFound
+	}
+	graphs := [2]*Graph{G, Transpose(G)}
+	paths := [2]*Paths{initSingleSource(src), initSingleSource(dst)}
+	open := [2]*heap.IndexedMinPQ{heap.NewIndexedMinPQ(), heap.NewIndexedMinPQ()}
+	open[0].Insert(src, 0)
+	open[1].Insert(dst, 0)
+	best, meet := infinity, src
+	if src == dst {
+		best = 0
+	}
+	for !open[0].Empty() && !open[1].Empty() {
+		_, k0, _ := open[0].Min()
+		_, k1, _ := open[1].Min()
+		if k0+k1 >= best {
+			break
+		}
+		side := 0
+		if k1 < k0 {
+			side = 1
+		}
+		H, p, other := graphs[side], paths[side], paths[1-side]
+		min, _, _ := open[side].ExtractMin()
+		u := H.V[min.(Label)]
+		for _, j := range u.Adj {
+			if p.relax(H, NewEdge(u, H.V[j])) {
+				enqueue(open[side], j, p.Distance[j])
+			}
+			if d, ok := other.Distance[j]; ok && p.Distance[j]+d < best {
+				best, meet = p.Distance[j]+d, j
+			}
+		}
+	}
+	if best == infinity {
+		return nil, ErrNoPath
+	}
+	path, err := PathTo(paths[0], meet)
+	if err != nil {
+		return nil, err
+	}
+	// the predecessors of the backward search lead on to dst
+	for v := meet; v != dst; {
+		v = paths[1].Predecessor[v]
+		path.Labels = append(path.Labels, v)
+	}
+	path.Weight = best
+	path.Hops = len(path.Labels) - 1
+	return path, nil
+}
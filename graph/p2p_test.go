@@ -0,0 +1,103 @@
+package graph
+
+import "testing"
+
+func TestPointToPoint(t *testing.T) {
+	graphs := map[string]*Graph{
+		"mst":  BuildUndirectedWeightedGraph(clrsMST),
+		"flow": BuildWeightedGraph(clrsFlow),
+		"maze": mazeGrid(),
+	}
+	for name, G := range graphs {
+		for _, src := range G.labels() {
+			p, _ := Dijkstra(G, src)
+			for _, dst := range G.labels() {
+				for fname, fn := range map[string]func(*Graph, Label, Label) (*Path, error){
+					"ShortestPath":          ShortestPath,
+					"BidirectionalDijkstra": BidirectionalDijkstra,
+				} {
+					path, err := fn(G, src, dst)
+					if !p.Reached(dst) {
+						if err != ErrNoPath {
+							t.Errorf("%s: %s(%s, %s): expected %v, got %v", name, fname, src, dst, ErrNoPath, err)
+						}
+						continue
+					}
+					if err != nil {
+						t.Fatalf("%s: %s(%s, %s): unexpected error: %v", name, fname, src, dst, err)
+					}
+					if path.Weight != p.Distance[dst] {
+						t.Errorf("%s: %s(%s, %s): expected weight %d, got %d", name, fname, src, dst, p.Distance[dst], path.Weight)
+					}
+					if path.Labels[0] != src || path.Labels[path.Hops] != dst {
+						t.Errorf("%s: %s(%s, %s): got path %s", name, fname, src, dst, path)
+					}
+					var w int
+					for i := 1; i < len(path.Labels); i++ {
+						x, ok := G.Weight(path.Labels[i-1], path.Labels[i])
+						if !ok {
+							t.Errorf("%s: %s(%s, %s): (%s, %s) is not an edge", name, fname, src, dst, path.Labels[i-1], path.Labels[i])
+						}
+						w += x
+					}
+					if w != path.Weight {
+						t.Errorf("%s: %s(%s, %s): path %s weighs %d, not %d", name, fname, src, dst, path, w, path.Weight)
+					}
+				}
+			}
+		}
+	}
+	G := BuildWeightedGraph(clrsFlow)
+	if _, err := BidirectionalDijkstra(G, "s", "x"); err != ErrVertexNotFound {
+		t.Errorf("expected %v, got %v", ErrVertexNotFound, err)
+	}
+}
+
+func BenchmarkShortestPath_Dijkstra(b *testing.B) {
+	// Dijkstra settles every vertex of the grid before
+	// the path to the cell in the middle is read off
+	G := BuildGrid(50, 50, nil)
+	src, dst := GridLabel(0, 0), GridLabel(25, 25)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		p, _ := Dijkstra(G, src)
+		PathTo(p, dst)
+	}
+}
+
+func BenchmarkShortestPath_EarlyExit(b *testing.B) {
+	// ShortestPath stops once the cell in the middle is settled,
+	// leaving the cells further from the corner unexplored
+	G := BuildGrid(50, 50, nil)
+	src, dst := GridLabel(0, 0), GridLabel(25, 25)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ShortestPath(G, src, dst)
+	}
+}
+
+func BenchmarkShortestPath_Bidirectional(b *testing.B) {
+	// BidirectionalDijkstra grows a ball around each end
+	// and stops when they meet half way
+	G := BuildGrid(50, 50, nil)
+	src, dst := GridLabel(0, 0), GridLabel(25, 25)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		BidirectionalDijkstra(G, src, dst)
+	}
+}
+
+func BenchmarkShortestPath_AStar(b *testing.B) {
+	// A* with the Manhattan distance heads straight for
+	// the cell in the middle on a grid without obstacles
+	G := BuildGrid(50, 50, nil)
+	src, dst := GridLabel(0, 0), GridLabel(25, 25)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		AStar(G, src, dst, Manhattan(dst))
+	}
+}
@@ -0,0 +1,60 @@
+package graph
+
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	// ErrNoPath occurs when the vertex a path is requested
+	// to was not reached from the source of the search
+	ErrNoPath = errors.New("no path")
+)
+
+// Path is a path from Labels[0] to Labels[len(Labels)-1]
+// Weight is its weight as given by the search it was found by,
+// which is the number of its edges for BFS, and Hops the
+// number of its edges
+type Path struct {
+	Labels []Label
+	Weight int
+	Hops   int
+}
+
+// String implements the Stringer interface to return a -> b -> c
+func (p *Path) String() string {
+	l := make([]string, len(p.Labels))
+	for i, j := range p.Labels {
+		l[i] = string(j)
+	}
+	return strings.Join(l, " -> ")
+}
+
+// PathTo returns the path from the source of p to dst found by
+// the search that computed p, such as BFS, Dijkstra or BellmanFord,
+// by walking the predecessors of dst back to the source
+// It returns ErrNoPath if dst was not reached
+// This is achieved in an order 0(V)-time
+func PathTo(p *Paths, dst Label) (*Path, error) {
+	d, ok := p.Distance[dst]
+	if !ok {
+		return nil, ErrNoPath
+	}
+	labels := []Label{dst}
+	for v := dst; v != p.Source; {
+		u, ok := p.Predecessor[v]
+		if !ok || len(labels) > len(p.Distance) {
+			return nil, ErrNoPath
+		}
+		labels = append(labels, u)
+		v = u
+	}
+	for i, j := 0, len(labels)-1; i < j; i, j = i+1, j-1 {
+		labels[i], labels[j] = labels[j], labels[i]
+	}
+	return &Path{
+		Labels: labels,
+		Weight: d,
+		Hops:   len(labels) - 1,
+	}, nil
+}
@@ -0,0 +1,44 @@
+package graph
+
+import "testing"
+
+func TestPathToResults(t *testing.T) {
+	G := BuildWeightedGraph(clrsNegative)
+	G.AddVertex("q")
+	bfs, _ := BFS(G, "s")
+	bf, _ := BellmanFord(G, "s")
+	ap, _ := FloydWarshall(G)
+	apPath := func(*Paths, Label) (*Path, error) { return ap.PathTo("s", "z") }
+	for name, tc := range map[string]struct {
+		p        *Paths
+		pathTo   func(*Paths, Label) (*Path, error)
+		expected string
+		weight   int
+	}{
+		"bfs":            {bfs, PathTo, "s -> t -> z", 2},
+		"bellman-ford":   {bf, PathTo, "s -> y -> x -> t -> z", -2},
+		"floyd-warshall": {nil, apPath, "s -> y -> x -> t -> z", -2},
+	} {
+		path, err := tc.pathTo(tc.p, "z")
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+		if path.String() != tc.expected || path.Weight != tc.weight || path.Hops != len(path.Labels)-1 {
+			t.Errorf("%s: expected %s of weight %d, got %s of weight %d", name, tc.expected, tc.weight, path, path.Weight)
+		}
+	}
+	if _, err := PathTo(bf, "q"); err != ErrNoPath {
+		t.Errorf("expected %v, got %v", ErrNoPath, err)
+	}
+	if _, err := ap.PathTo("s", "q"); err != ErrNoPath {
+		t.Errorf("expected %v, got %v", ErrNoPath, err)
+	}
+	if path, err := PathTo(bf, "s"); err != nil || path.Hops != 0 || path.String() != "s" {
+		t.Errorf("expected the path s, got %v: %v", path, err)
+	}
+	G = BuildWeightedGraph(clrsFlow)
+	dj, _ := Dijkstra(G, "s")
+	if path, err := PathTo(dj, "t"); err != nil || path.Weight != 31 {
+		t.Errorf("expected a path of weight 31, got %v: %v", path, err)
+	}
+}
@@ -4,88 +4,152 @@ import (
 	"github.com/willpoint/algor/heap"
 )
 
+// infinity is the shortest-path estimate of a vertex
+// that has not yet been reached from the source
+const infinity = int(^uint(0) >> 1)
+
+// NegativeCycleError is returned by BellmanFord when a
+// negative-weight cycle is reachable from the source,
+// Cycle holds the labels of the vertices on the cycle in
+// the order they are traversed
+type NegativeCycleError struct {
+	Cycle []Label
+}
+
+// Error implements the error interface
+func (e *NegativeCycleError) Error() string {
+	return "negative-weight " + formatCycle(e.Cycle)
+}
+
 // initSingleSource initializes the shortest-path
 // estimates for all vertices in graph G, and sets
 // the estimate for vertex labeled s to 0
 // The estimate is an upper bound on the weight of a shortest
-// path from source s to v - which would be set to the largest
-// int value depending on the target architecture
-// after initialization - v.Distance = max for all v ∈ V - {src}
-// This is achieved in an order 0(V)-time, where V is the number of Vertices
-func (G *Graph) initSingleSource(src Label) {
-	for _, v := range G.V {
-		v.Distance = int(^uint(0) >> 1)
-	}
-	G.V[src].Distance = 0
+// path from source s to v - a vertex with no estimate in the
+// returned Paths has an estimate of infinity
+// after initialization - d(v) = infinity for all v ∈ V - {src}
+// This is achieved in an order 0(1)-time
+func initSingleSource(src Label) *Paths {
+	return newPaths(src)
 }
 
 // relaxing an edge (u, v) is done based on a condition
 // that tells if we can improve the shortest path to v
 // found so far by going through u and, if the condition
-// passes, update v.Distance and v.Predecessor
+// passes, update the distance and predecessor of v in p
+// An edge leaving a vertex that is yet to be reached is never
+// relaxed, as its estimate is infinite
+// It reports whether the estimate of v was improved
 // This is achieved in an order 0(1)-time
-func (G *Graph) relax(e Edge) {
-	w := G.E[e]
-	improved := e.V.Distance > e.U.Distance+w
+func (p *Paths) relax(G *Graph, e Edge) bool {
+	du, ok := p.Distance[e.U.Label]
+	if !ok {
+		return false
+	}
+	w := G.weight(e)
+	dv, ok := p.Distance[e.V.Label]
+	improved := !ok || dv > du+w
 	if improved {
-		e.V.Distance = e.U.Distance + w
-		e.V.Predecessor = e.U
+		p.Distance[e.V.Label] = du + w
+		p.Predecessor[e.V.Label] = e.U.Label
 	}
+	return improved
 }
 
-// vertices satisfy heap.Heaper interface for binary priority queue
-type vertices []*Vertex
-
-func (v vertices) Len() int                            { return len(v) }
-func (v vertices) Smaller(i int, key interface{}) bool { return v[i].Distance < key.(*Vertex).Distance }
-func (v vertices) Less(i, j int) bool                  { return v[i].Distance < v[j].Distance }
-func (v vertices) Get(i int) interface{}               { return v[i] }
-
-func (v *vertices) Pop() interface{} {
-	n := *v
-	i, j := n[0], n[1:]
-	*v = j
-	return i
+// enqueue inserts l into Q with the given key, or
+// lowers the key of l if l is already in Q
+func enqueue(Q *heap.IndexedMinPQ, l Label, key int) {
+	if !Q.DecreaseKey(l, key) {
+		Q.Insert(l, key)
+	}
 }
 
-func (v *vertices) Push(x interface{}) {
-	n := *v
-	n = append(n, x.(*Vertex))
+// Dijkstra solves the shortest-paths problem on a weighted,
+// directed graph G, for which all edge weights are nonnegative
+// The vertices reached but not yet settled are held in an indexed
+// min-priority queue keyed on their estimates, and the key of a
+// vertex is decreased in place whenever an edge into it is relaxed
+// Vertices are extracted from the queue in the order their final
+// shortest-path weights from the source src are determined
+// This is achieved in an order 0((V + E) lg V)-time
+func Dijkstra(G *Graph, src Label) (*Paths, error) {
+	if _, ok := G.V[src]; !ok {
+		return nil, ErrVertexNotFound
+	}
+	p := initSingleSource(src)
+	Q := heap.NewIndexedMinPQ()
+	Q.Insert(src, 0)
+	for !Q.Empty() {
+		min, _, _ := Q.ExtractMin()
+		u := G.V[min.(Label)]
+		for _, j := range u.Adj {
+			if p.relax(G, NewEdge(u, G.V[j])) {
+				enqueue(Q, j, p.Distance[j])
+			}
+		}
+	}
+	return p, nil
 }
 
-func (v *vertices) Swap(i, j int) {
-	n := *v
-	n[i], n[j] = n[j], n[i]
+// BellmanFord solves the single-source shortest-paths problem
+// in the general case in which edge weights may be negative
+// It relaxes every edge of G |V| - 1 times, after which the
+// estimate of each vertex reachable from src is its shortest-path
+// weight, provided no negative-weight cycle is reachable from src
+// A final pass over the edges detects such a cycle, in which case
+// a *NegativeCycleError naming the vertices of the cycle is returned
+// This is achieved in an order 0(VE)-time
+func BellmanFord(G *Graph, src Label) (*Paths, error) {
+	if _, ok := G.V[src]; !ok {
+		return nil, ErrVertexNotFound
+	}
+	p := initSingleSource(src)
+	if err := p.relaxEdges(G, G.VNum); err != nil {
+		return nil, err
+	}
+	return p, nil
 }
 
-func (v *vertices) Set(i int, x interface{}) {
-	n := *v
-	n[i] = x.(*Vertex)
+// relaxEdges makes n - 1 passes relaxing every edge of G, stopping
+// early once a pass improves no estimate, where n is the number of
+// vertices the estimates were initialized from
+// An edge of an undirected graph is relaxed in both directions
+// It returns a *NegativeCycleError if an estimate still improves
+// after the last pass
+func (p *Paths) relaxEdges(G *Graph, n int) error {
+	edges := G.arcs()
+	for i := 1; i < n; i++ {
+		var changed bool
+		for _, e := range edges {
+			if p.relax(G, e) {
+				changed = true
+			}
+		}
+		if !changed {
+			return nil
+		}
+	}
+	for _, e := range edges {
+		if p.relax(G, e) {
+			return &NegativeCycleError{Cycle: p.negativeCycle(e.V.Label, n)}
+		}
+	}
+	return nil
 }
 
-// Dijkstra solves the shortest-paths problem on a weighted,
-// directed graph G, for which all edge weights are nonnegative
-// in order of 0((V+E)log V) using a binary min-heap priority queue
-// It maintains a map S of vertices whose final shortest-path weights
-// from the source src have already been determined
-func Dijkstra(G *Graph, src Label) []*Vertex {
-	G.initSingleSource(src)
-	S := []*Vertex{}
-	var V vertices
-	for _, v := range G.V {
-		V = append(V, v)
+// negativeCycle walks the predecessor chain from v, which was
+// improved after n - 1 passes, n times to be certain to land
+// on the cycle and then collects the labels of the cycle in order
+func (p *Paths) negativeCycle(v Label, n int) []Label {
+	for i := 0; i < n; i++ {
+		v = p.Predecessor[v]
 	}
-	Q := heap.NewBinaryHeap(&V)
-	Q.BuildMinHeap()
-	for !Q.Empty() {
-		min, _ := Q.ExtractMin()
-		u := min.(*Vertex)
-		S = append(S, u)
-		for _, j := range u.Adj {
-			v := G.V[j]
-			G.relax(Edge{u, v})
-		}
-		Q.MinHeapify(0)
+	cycle := []Label{v}
+	for u := p.Predecessor[v]; u != v; u = p.Predecessor[u] {
+		cycle = append(cycle, u)
+	}
+	for i, j := 0, len(cycle)-1; i < j; i, j = i+1, j-1 {
+		cycle[i], cycle[j] = cycle[j], cycle[i]
 	}
-	return S
+	return cycle
 }
@@ -0,0 +1,72 @@
+package graph
+
+import "testing"
+
+// weightedPair mirrors the element type accepted by BuildWeightedGraph
+type weightedPair = struct {
+	Pair   [2]string `json:"pair"`
+	Weight int       `json:"weight"`
+}
+
+// clrsNegative is the graph of figure 24.4 in CLRS
+// with negative weight edges but no negative-weight cycle
+var clrsNegative = []weightedPair{
+	{Pair: [2]string{"s", "t"}, Weight: 6},
+	{Pair: [2]string{"s", "y"}, Weight: 7},
+	{Pair: [2]string{"t", "x"}, Weight: 5},
+	{Pair: [2]string{"t", "y"}, Weight: 8},
+	{Pair: [2]string{"t", "z"}, Weight: -4},
+	{Pair: [2]string{"x", "t"}, Weight: -2},
+	{Pair: [2]string{"y", "x"}, Weight: -3},
+	{Pair: [2]string{"y", "z"}, Weight: 9},
+	{Pair: [2]string{"z", "x"}, Weight: 7},
+	{Pair: [2]string{"z", "s"}, Weight: 2},
+}
+
+func TestBellmanFord(t *testing.T) {
+	G := BuildWeightedGraph(clrsNegative)
+	p, err := BellmanFord(G, Label("s"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := map[Label]int{"s": 0, "t": 2, "x": 4, "y": 7, "z": -2}
+	for l, d := range expected {
+		if got := p.Distance[l]; got != d {
+			t.Errorf("expected distance of %s to be %d, got %d", l, d, got)
+		}
+	}
+	if _, err := BellmanFord(G, Label("q")); err != ErrVertexNotFound {
+		t.Errorf("expected %v, got %v", ErrVertexNotFound, err)
+	}
+}
+
+func TestBellmanFordNegativeCycle(t *testing.T) {
+	G := BuildWeightedGraph([]weightedPair{
+		{Pair: [2]string{"s", "a"}, Weight: 1},
+		{Pair: [2]string{"a", "b"}, Weight: 1},
+		{Pair: [2]string{"b", "c"}, Weight: -3},
+		{Pair: [2]string{"c", "a"}, Weight: 1},
+		{Pair: [2]string{"c", "d"}, Weight: 4},
+	})
+	_, err := BellmanFord(G, Label("s"))
+	nc, ok := err.(*NegativeCycleError)
+	if !ok {
+		t.Fatalf("expected a *NegativeCycleError, got %v", err)
+	}
+	if len(nc.Cycle) != 3 {
+		t.Fatalf("expected cycle of 3 vertices, got %v", nc.Cycle)
+	}
+	var sum int
+	for i, u := range nc.Cycle {
+		v := nc.Cycle[(i+1)%len(nc.Cycle)]
+		w, ok := G.E[NewEdge(G.V[u], G.V[v])]
+		if !ok {
+			t.Fatalf("(%s, %s) is not an edge of the cycle %v", u, v, nc.Cycle)
+		}
+		sum += w
+	}
+	if sum >= 0 {
+		t.Errorf("expected cycle %v to have negative weight, got %d", nc.Cycle, sum)
+	}
+	t.Log(err)
+}
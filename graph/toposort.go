@@ -0,0 +1,142 @@
+package graph
+
+import (
+	"container/heap"
+	"strings"
+)
+
+// CycleError is returned when an ordering of the vertices of G
+// is requested but G is not acyclic, Cycle holds the labels of the
+// vertices on one of its cycles in the order they are traversed
+type CycleError struct {
+	Cycle []Label
+}
+
+// Error implements the error interface
+func (e *CycleError) Error() string {
+	return formatCycle(e.Cycle)
+}
+
+// formatCycle returns the cycle as "cycle: a -> b -> c -> a"
+func formatCycle(cycle []Label) string {
+	l := make([]string, 0, len(cycle)+1)
+	for _, j := range cycle {
+		l = append(l, string(j))
+	}
+	if len(cycle) > 0 {
+		l = append(l, string(cycle[0]))
+	}
+	return "cycle: " + strings.Join(l, " -> ")
+}
+
+// labelHeap is a min-heap of labels for container/heap
+type labelHeap []Label
+
+func (h labelHeap) Len() int            { return len(h) }
+func (h labelHeap) Less(i, j int) bool  { return h[i] < h[j] }
+func (h labelHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *labelHeap) Push(x interface{}) { *h = append(*h, x.(Label)) }
+
+func (h *labelHeap) Pop() interface{} {
+	n := *h
+	x := n[len(n)-1]
+	*h = n[:len(n)-1]
+	return x
+}
+
+// inDegrees returns the number of edges entering each vertex of G
+func inDegrees(G *Graph) map[Label]int {
+	in := make(map[Label]int, len(G.V))
+	for l, u := range G.V {
+		if _, ok := in[l]; !ok {
+			in[l] = 0
+		}
+		for _, v := range u.Adj {
+			in[v]++
+		}
+	}
+	return in
+}
+
+// Kahn produces a topological sort of G by repeatedly removing a
+// vertex with no entering edges, along with the edges leaving it.
+// When more than one vertex is free to be removed the one with the
+// smallest label is chosen, which makes the ordering deterministic -
+// the lexicographically smallest topological sort of G.
+// If vertices remain once none is free, G has a cycle which is
+// reported as a *CycleError, and ErrUndirected is returned for an
+// undirected graph
+// This is achieved in an order 0(E + V log V)-time
+func Kahn(G *Graph) ([]Label, error) {
+	if G.Undirected {
+		return nil, ErrUndirected
+	}
+	in := inDegrees(G)
+	var Q labelHeap
+	for l, d := range in {
+		if d == 0 {
+			Q = append(Q, l)
+		}
+	}
+	heap.Init(&Q)
+	order := make([]Label, 0, len(G.V))
+	for Q.Len() > 0 {
+		u := heap.Pop(&Q).(Label)
+		order = append(order, u)
+		for _, v := range G.V[u].Adj {
+			in[v]--
+			if in[v] == 0 {
+				heap.Push(&Q, v)
+			}
+		}
+	}
+	if len(order) < len(G.V) {
+		_, err := TopoSort(G)
+		return nil, err
+	}
+	return order, nil
+}
+
+// TopoLayers partitions the vertices of G into layers such that
+// every edge (u, v) leads from a layer to a later one. The first
+// layer holds the vertices with no entering edges and each following
+// layer the vertices whose entering edges all leave earlier layers,
+// so the vertices of a layer do not depend on each other and may be
+// processed in parallel once the previous layers are done.
+// The labels of each layer are sorted, and a *CycleError is returned
+// if G is not acyclic, or ErrUndirected if G is undirected
+// This is achieved in an order 0(E + V log V)-time
+func TopoLayers(G *Graph) ([][]Label, error) {
+	if G.Undirected {
+		return nil, ErrUndirected
+	}
+	in := inDegrees(G)
+	var layer []Label
+	for l, d := range in {
+		if d == 0 {
+			layer = append(layer, l)
+		}
+	}
+	var layers [][]Label
+	var n int
+	for len(layer) > 0 {
+		sortLabels(layer)
+		layers = append(layers, layer)
+		n += len(layer)
+		var next []Label
+		for _, u := range layer {
+			for _, v := range G.V[u].Adj {
+				in[v]--
+				if in[v] == 0 {
+					next = append(next, v)
+				}
+			}
+		}
+		layer = next
+	}
+	if n < len(G.V) {
+		_, err := TopoSort(G)
+		return nil, err
+	}
+	return layers, nil
+}
@@ -0,0 +1,100 @@
+package graph
+
+import (
+	"fmt"
+	"testing"
+)
+
+// clrsDress is the graph of figure 22.7 in CLRS
+var clrsDress = [][2]string{
+	{"undershorts", "pants"}, {"undershorts", "shoes"},
+	{"pants", "belt"}, {"pants", "shoes"},
+	{"belt", "jacket"}, {"shirt", "belt"}, {"shirt", "tie"},
+	{"tie", "jacket"}, {"socks", "shoes"}, {"watch", ""},
+}
+
+// precedes checks that u appears before v in order for every edge (u, v)
+func precedes(t *testing.T, G *Graph, order []Label) {
+	pos := make(map[Label]int, len(order))
+	for i, l := range order {
+		pos[l] = i
+	}
+	if len(pos) != G.VNum {
+		t.Fatalf("expected %d vertices in %v", G.VNum, order)
+	}
+	for e := range G.E {
+		if pos[e.U.Label] >= pos[e.V.Label] {
+			t.Errorf("expected %s to precede %s in %v", e.U.Label, e.V.Label, order)
+		}
+	}
+}
+
+func TestTopoSortOrder(t *testing.T) {
+	G := BuildGraph(clrsDress)
+	l, err := TopoSort(G)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var order []Label
+	for n := l.Head; n != nil; n = n.Next {
+		order = append(order, Label(n.E))
+	}
+	precedes(t, G, order)
+}
+
+func TestTopoSortCycle(t *testing.T) {
+	G := BuildGraph([][2]string{{"a", "b"}, {"b", "c"}, {"c", "d"}, {"d", "b"}})
+	_, err := TopoSort(G)
+	ce, ok := err.(*CycleError)
+	if !ok {
+		t.Fatalf("expected a *CycleError, got %v", err)
+	}
+	if s := fmt.Sprint(ce.Cycle); s != "[b c d]" {
+		t.Errorf("expected cycle [b c d], got %s", s)
+	}
+	if _, err := Kahn(G); err == nil {
+		t.Errorf("expected kahn to report a cycle")
+	}
+	if _, err := TopoLayers(G); err == nil {
+		t.Errorf("expected topological layers to report a cycle")
+	}
+	t.Log(err)
+}
+
+func TestKahn(t *testing.T) {
+	G := BuildGraph(clrsDress)
+	order, err := Kahn(G)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	precedes(t, G, order)
+	expected := "[shirt socks tie undershorts pants belt jacket shoes watch]"
+	if s := fmt.Sprint(order); s != expected {
+		t.Errorf("expected %s, got %s", expected, s)
+	}
+}
+
+func TestTopoLayers(t *testing.T) {
+	G := BuildGraph(clrsDress)
+	layers, err := TopoLayers(G)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := "[[shirt socks undershorts watch] [pants tie] [belt shoes] [jacket]]"
+	if s := fmt.Sprint(layers); s != expected {
+		t.Errorf("expected %s, got %s", expected, s)
+	}
+}
+
+func TestTopoSortUndirected(t *testing.T) {
+	G := BuildUndirectedGraph([][2]string{{"a", "b"}})
+	if _, err := TopoSort(G); err != ErrUndirected {
+		t.Errorf("TopoSort: expected %v, got %v", ErrUndirected, err)
+	}
+	if _, err := Kahn(G); err != ErrUndirected {
+		t.Errorf("Kahn: expected %v, got %v", ErrUndirected, err)
+	}
+	if _, err := TopoLayers(G); err != ErrUndirected {
+		t.Errorf("TopoLayers: expected %v, got %v", ErrUndirected, err)
+	}
+}
@@ -0,0 +1,120 @@
+package graph
+
+import (
+	"errors"
+
+	"github.com/willpoint/algor/disjointset"
+)
+
+var (
+	// ErrUndirected occurs when an algorithm that
+	// requires a directed graph is given an undirected one
+	ErrUndirected = errors.New("graph is undirected")
+)
+
+// NewUndirectedGraph returns a reference to a new undirected Graph G
+// in which an edge {u, v} connects u and v both ways, it is held once
+// in E and each of u and v is in the adjacency list of the other
+func NewUndirectedGraph() *Graph {
+	G := NewGraph()
+	G.Undirected = true
+	return G
+}
+
+// BuildUndirectedGraph initializes an undirected Graph G = (V, E)
+// from pairs as BuildGraph does, (u, v) and (v, u) being the same edge
+func BuildUndirectedGraph(pairs [][2]string) *Graph {
+	return addPairs(NewUndirectedGraph(), pairs)
+}
+
+// BuildUndirectedWeightedGraph initializes an undirected Graph
+// G = (V, E) with weighted edges as BuildWeightedGraph does
+func BuildUndirectedWeightedGraph(pairs []struct {
+	Pair   [2]string `json:"pair"`
+	Weight int       `json:"weight"`
+}) *Graph {
+	return addWeightedPairs(NewUndirectedGraph(), pairs)
+}
+
+// key returns the edge under which (u, v) is held in E, which is
+// {u, v} ordered by label for an undirected graph
+func (G *Graph) key(e Edge) Edge {
+	if G.Undirected && e.V.Label < e.U.Label {
+		return NewEdge(e.V, e.U)
+	}
+	return e
+}
+
+// weight returns the weight of the edge (u, v),
+// whichever way it is held in E
+func (G *Graph) weight(e Edge) int {
+	return G.E[G.key(e)]
+}
+
+// arcs returns the edges of G, each edge {u, v} of an undirected
+// graph given as both (u, v) and (v, u)
+func (G *Graph) arcs() []Edge {
+	arcs := make([]Edge, 0, len(G.E))
+	for e := range G.E {
+		arcs = append(arcs, e)
+		if G.Undirected && e.U != e.V {
+			arcs = append(arcs, NewEdge(e.V, e.U))
+		}
+	}
+	return arcs
+}
+
+// neighbors returns the sorted labels of the vertices joined to
+// each vertex of G by an edge, regardless of its direction, with
+// self-loops left out and each pair of vertices joined at most once
+func (G *Graph) neighbors() map[Label][]Label {
+	nbrs := make(map[Label][]Label, len(G.V))
+	for e := range G.E {
+		u, v := e.U.Label, e.V.Label
+		if u == v || G.hasEdge(v, u) && v < u {
+			continue
+		}
+		nbrs[u] = append(nbrs[u], v)
+		nbrs[v] = append(nbrs[v], u)
+	}
+	for _, l := range nbrs {
+		sortLabels(l)
+	}
+	return nbrs
+}
+
+// hasEdge reports whether G has the edge (u, v)
+func (G *Graph) hasEdge(u, v Label) bool {
+	_, ok := G.Weight(u, v)
+	return ok
+}
+
+// ConnectedComponents returns the connected components of G, the
+// sets of vertices that are reachable from each other through its
+// edges regardless of their direction, that is the weakly connected
+// components of a directed graph.
+// The labels of each component are sorted, and the components are
+// ordered by their smallest label
+// This is achieved in an order 0((V+E) α(V))-time with a disjoint-set forest
+func ConnectedComponents(G *Graph) [][]Label {
+	f := disjointset.NewForest()
+	for l := range G.V {
+		f.MakeSet(l)
+	}
+	for e := range G.E {
+		f.Union(e.U.Label, e.V.Label)
+	}
+	index := make(map[interface{}]int, f.Sets())
+	var components [][]Label
+	for _, l := range G.labels() {
+		r, _ := f.FindSet(l)
+		i, ok := index[r]
+		if !ok {
+			i = len(components)
+			index[r] = i
+			components = append(components, nil)
+		}
+		components[i] = append(components[i], l)
+	}
+	return components
+}
@@ -0,0 +1,88 @@
+package graph
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestUndirectedGraph(t *testing.T) {
+	var pairs [][2]string
+	for i := 0; i < len(clrsBFS); i += 2 {
+		pairs = append(pairs, clrsBFS[i])
+	}
+	G := BuildUndirectedGraph(append(pairs, [2]string{"s", "r"}))
+	consistent(t, G)
+	if G.ENum != 10 {
+		t.Errorf("expected %d edges, got %d", 10, G.ENum)
+	}
+	if err := G.AddEdge("v", "r", 1); err != ErrEdgeExists {
+		t.Errorf("expected %v, got %v", ErrEdgeExists, err)
+	}
+	p, _ := BFS(G, "s")
+	expected := map[Label]int{"r": 1, "s": 0, "t": 2, "u": 3, "v": 2, "w": 1, "x": 2, "y": 3}
+	for l, d := range expected {
+		if p.Distance[l] != d {
+			t.Errorf("expected distance of %s to be %d, got %d", l, d, p.Distance[l])
+		}
+	}
+	if err := G.RemoveEdge("x", "w"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := G.RemoveVertex("t"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	consistent(t, G)
+	if s := fmt.Sprint(ConnectedComponents(G)); s != "[[r s v w] [u x y]]" {
+		t.Errorf("expected components [[r s v w] [u x y]], got %s", s)
+	}
+}
+
+func TestUndirectedWeightedGraph(t *testing.T) {
+	G := BuildUndirectedWeightedGraph(clrsMST)
+	if G.ENum != len(clrsMST) {
+		t.Errorf("expected %d edges, got %d", len(clrsMST), G.ENum)
+	}
+	if w, ok := G.Weight("b", "a"); !ok || w != 4 {
+		t.Errorf("expected weight of {a, b} to be %d, got %d", 4, w)
+	}
+	for name, mst := range map[string]func(*Graph) ([]Edge, int){
+		"kruskal": Kruskal,
+		"prim":    Prim,
+	} {
+		if _, w := mst(G); w != 37 {
+			t.Errorf("%s: expected total weight %d, got %d", name, 37, w)
+		}
+	}
+	// e is only reachable by going against the direction
+	// in which the edges were given
+	p, err := Dijkstra(G, "e")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	bf, err := BellmanFord(G, "e")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ap, err := FloydWarshall(G)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := map[Label]int{"a": 21, "b": 22, "c": 14, "d": 9, "e": 0, "f": 10, "g": 12, "h": 13, "i": 16}
+	for l, d := range expected {
+		if p.Distance[l] != d || bf.Distance[l] != d {
+			t.Errorf("expected distance of %s to be %d, got %d and %d", l, d, p.Distance[l], bf.Distance[l])
+		}
+		if dd, _ := ap.Distance(l, "e"); dd != d {
+			t.Errorf("expected d(%s, e) to be %d, got %d", l, d, dd)
+		}
+	}
+	fl, err := EdmondsKarp(BuildUndirectedWeightedGraph(clrsFlow), "t", "s")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// no edge leaves t in the directed network
+	// while here it is bound by the edges of t
+	if fl.Value != 24 {
+		t.Errorf("expected flow value %d, got %d", 24, fl.Value)
+	}
+}
@@ -0,0 +1,131 @@
+package graph
+
+import (
+	"strings"
+
+	"github.com/willpoint/algor/heap"
+)
+
+// KShortestPaths returns up to k loopless paths from src to dst in
+// a graph G with nonnegative edge weights, in nondecreasing order of
+// weight, using Yen's algorithm
+// Having found the i-th shortest path, each of its vertices in turn
+// is taken as a spur vertex, and a shortest path from it to dst that
+// avoids the vertices of the root path leading to it and the edges
+// leaving it along the root path in the paths found so far is joined
+// to the root path as a candidate; the lightest candidate not yet
+// taken is the (i+1)-th shortest path, with ties broken by number of
+// edges and then by labels
+// It returns ErrNoPath if dst is not reachable from src
+// This is achieved in an order 0(kV(V + E) lg V)-time
+func KShortestPaths(G *Graph, src, dst Label, k int) ([]*Path, error) {
+	first, err := ShortestPath(G, src, dst)
+	if err != nil || k < 1 {
+		return nil, err
+	}
+	A := []*Path{first}
+	var B []*Path
+	seen := map[string]bool{labelsKey(first.Labels): true}
+	for len(A) < k {
+		prev := A[len(A)-1]
+		var rootWeight int
+		for i := 0; i < prev.Hops; i++ {
+			root := prev.Labels[:i+1]
+			removedV := make(map[Label]bool, i)
+			for _, l := range root[:i] {
+				removedV[l] = true
+			}
+			removedE := make(map[[2]Label]bool)
+			for _, p := range A {
+				if p.Hops > i && sameLabels(p.Labels[:i+1], root) {
+					removedE[[2]Label{p.Labels[i], p.Labels[i+1]}] = true
+				}
+			}
+			if spur, err := restrictedPath(G, prev.Labels[i], dst, removedV, removedE); err == nil {
+				labels := append(append([]Label{}, root[:i]...), spur.Labels...)
+				p := &Path{
+					Labels: labels,
+					Weight: rootWeight + spur.Weight,
+					Hops:   len(labels) - 1,
+				}
+				if key := labelsKey(p.Labels); !seen[key] {
+					seen[key] = true
+					B = append(B, p)
+				}
+			}
+			w, _ := G.Weight(prev.Labels[i], prev.Labels[i+1])
+			rootWeight += w
+		}
+		if len(B) == 0 {
+			break
+		}
+		min := 0
+		for j := range B {
+			if lighter(B[j], B[min]) {
+				min = j
+			}
+		}
+		A = append(A, B[min])
+		B = append(B[:min], B[min+1:]...)
+	}
+	return A, nil
+}
+
+// restrictedPath returns a shortest path from src to dst by running
+// Dijkstra's algorithm from src until dst is settled, without entering
+// the vertices in removedV or following the edges in removedE
+func restrictedPath(G *Graph, src, dst Label, removedV map[Label]bool, removedE map[[2]Label]bool) (*Path, error) {
+	p := initSingleSource(src)
+	Q := heap.NewIndexedMinPQ()
+	Q.Insert(src, 0)
+	for !Q.Empty() {
+		min, _, _ := Q.ExtractMin()
+		u := G.V[min.(Label)]
+		if u.Label == dst {
+			return PathTo(p, dst)
+		}
+		for _, j := range u.Adj {
+			if removedV[j] || removedE[[2]Label{u.Label, j}] {
+				continue
+			}
+			if p.relax(G, NewEdge(u, G.V[j])) {
+				enqueue(Q, j, p.Distance[j])
+			}
+		}
+	}
+	return nil, ErrNoPath
+}
+
+// lighter orders paths by weight, then by
+// number of edges and then by their labels
+func lighter(p, q *Path) bool {
+	if p.Weight != q.Weight {
+		return p.Weight < q.Weight
+	}
+	if p.Hops != q.Hops {
+		return p.Hops < q.Hops
+	}
+	return p.String() < q.String()
+}
+
+// labelsKey joins labels with a byte no label is expected to hold,
+// so that distinct label sequences give distinct keys
+func labelsKey(labels []Label) string {
+	s := make([]string, len(labels))
+	for i, l := range labels {
+		s[i] = string(l)
+	}
+	return strings.Join(s, "\x00")
+}
+
+func sameLabels(a, b []Label) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
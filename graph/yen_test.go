@@ -0,0 +1,85 @@
+package graph
+
+import "testing"
+
+// yenExample is the graph commonly used to illustrate Yen's algorithm
+var yenExample = []weightedPair{
+	{Pair: [2]string{"C", "D"}, Weight: 3},
+	{Pair: [2]string{"C", "E"}, Weight: 2},
+	{Pair: [2]string{"D", "F"}, Weight: 4},
+	{Pair: [2]string{"E", "D"}, Weight: 1},
+	{Pair: [2]string{"E", "F"}, Weight: 2},
+	{Pair: [2]string{"E", "G"}, Weight: 3},
+	{Pair: [2]string{"F", "G"}, Weight: 2},
+	{Pair: [2]string{"F", "H"}, Weight: 1},
+	{Pair: [2]string{"G", "H"}, Weight: 2},
+}
+
+func TestKShortestPaths(t *testing.T) {
+	G := BuildWeightedGraph(yenExample)
+	expected := []struct {
+		path   string
+		weight int
+	}{
+		{"C -> E -> F -> H", 5},
+		{"C -> E -> G -> H", 7},
+		{"C -> D -> F -> H", 8},
+		{"C -> E -> D -> F -> H", 8},
+		{"C -> E -> F -> G -> H", 8},
+		{"C -> D -> F -> G -> H", 11},
+		{"C -> E -> D -> F -> G -> H", 11},
+	}
+	paths, err := KShortestPaths(G, "C", "H", 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(paths) != len(expected) {
+		t.Fatalf("expected %d paths, got %d: %v", len(expected), len(paths), paths)
+	}
+	for i, p := range paths {
+		if p.String() != expected[i].path || p.Weight != expected[i].weight {
+			t.Errorf("expected path %d to be %s of weight %d, got %s of weight %d", i, expected[i].path, expected[i].weight, p, p.Weight)
+		}
+	}
+	if paths, _ := KShortestPaths(G, "C", "H", 2); len(paths) != 2 {
+		t.Errorf("expected 2 paths, got %d", len(paths))
+	}
+	if _, err := KShortestPaths(G, "H", "C", 3); err != ErrNoPath {
+		t.Errorf("expected %v, got %v", ErrNoPath, err)
+	}
+}
+
+func TestKShortestPathsUndirected(t *testing.T) {
+	G := BuildGrid(3, 3, nil)
+	paths, err := KShortestPaths(G, GridLabel(0, 0), GridLabel(2, 2), 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// the 6 paths of 4 moves come first
+	for i, p := range paths {
+		seen := make(map[Label]bool)
+		for _, l := range p.Labels {
+			if seen[l] {
+				t.Errorf("expected path %s to be loopless", p)
+			}
+			seen[l] = true
+		}
+		if i > 0 && p.Weight < paths[i-1].Weight {
+			t.Errorf("expected path %s to come before %s", p, paths[i-1])
+		}
+	}
+	if len(paths) != 10 || paths[5].Weight != 4 || paths[6].Weight != 6 {
+		t.Errorf("expected 6 paths of weight 4 followed by paths of weight 6, got %v", paths)
+	}
+}
+
+func TestKShortestPathsArrowLabels(t *testing.T) {
+	G := BuildGraph([][2]string{{"s", "x -> y"}, {"x -> y", "t"}, {"s", "x"}, {"x", "y"}, {"y", "t"}})
+	paths, err := KShortestPaths(G, "s", "t", 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(paths) != 2 || paths[0].String() != paths[1].String() {
+		t.Errorf("expected 2 paths written alike, got %v", paths)
+	}
+}
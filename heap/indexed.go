@@ -0,0 +1,141 @@
+package heap
+
+// IndexedMinPQ is a min-priority queue of items, each identified by
+// a comparable handle such as a vertex label, kept in a binary min-heap
+// on their integer keys. An index from each handle to its position in
+// the heap lets the key of any item be looked up and decreased
+// without searching for it, as Dijkstra's and Prim's algorithms need
+type IndexedMinPQ struct {
+	items []interface{}
+	key   map[interface{}]int
+	index map[interface{}]int
+}
+
+// NewIndexedMinPQ returns an empty indexed min-priority queue
+func NewIndexedMinPQ() *IndexedMinPQ {
+	return &IndexedMinPQ{
+		key:   make(map[interface{}]int),
+		index: make(map[interface{}]int),
+	}
+}
+
+// Len returns the number of items in the queue
+func (q *IndexedMinPQ) Len() int {
+	return len(q.items)
+}
+
+// Empty returns boolean signifying if the queue is empty
+func (q *IndexedMinPQ) Empty() bool {
+	return len(q.items) == 0
+}
+
+// Contains returns boolean signifying if x is in the queue
+func (q *IndexedMinPQ) Contains(x interface{}) bool {
+	_, ok := q.index[x]
+	return ok
+}
+
+// Key returns the key of x and whether x is in the queue
+func (q *IndexedMinPQ) Key(x interface{}) (int, bool) {
+	if !q.Contains(x) {
+		return 0, false
+	}
+	return q.key[x], true
+}
+
+// Insert adds x to the queue with the given key, it returns
+// false and leaves the queue unchanged if x is already in it
+// This is achieved in an order 0(lg n)-time
+func (q *IndexedMinPQ) Insert(x interface{}, key int) bool {
+	if q.Contains(x) {
+		return false
+	}
+	q.items = append(q.items, x)
+	q.index[x] = len(q.items) - 1
+	q.key[x] = key
+	q.up(len(q.items) - 1)
+	return true
+}
+
+// DecreaseKey lowers the key of x to key, it returns false and
+// leaves the queue unchanged if x is not in the queue or key is
+// not smaller than the key of x
+// This is achieved in an order 0(lg n)-time
+func (q *IndexedMinPQ) DecreaseKey(x interface{}, key int) bool {
+	i, ok := q.index[x]
+	if !ok || key >= q.key[x] {
+		return false
+	}
+	q.key[x] = key
+	q.up(i)
+	return true
+}
+
+// Min returns the item with the minimum key and its key
+// without removing it, it returns false if the queue is empty
+func (q *IndexedMinPQ) Min() (interface{}, int, bool) {
+	if q.Empty() {
+		return nil, 0, false
+	}
+	return q.items[0], q.key[q.items[0]], true
+}
+
+// ExtractMin removes and returns the item with the minimum key
+// and its key, it returns false if the queue is empty
+// This is achieved in an order 0(lg n)-time
+func (q *IndexedMinPQ) ExtractMin() (interface{}, int, bool) {
+	if q.Empty() {
+		return nil, 0, false
+	}
+	min, key := q.items[0], q.key[q.items[0]]
+	last := len(q.items) - 1
+	q.swap(0, last)
+	q.items = q.items[:last]
+	delete(q.index, min)
+	delete(q.key, min)
+	q.down(0)
+	return min, key, true
+}
+
+func (q *IndexedMinPQ) less(i, j int) bool {
+	return q.key[q.items[i]] < q.key[q.items[j]]
+}
+
+func (q *IndexedMinPQ) swap(i, j int) {
+	q.items[i], q.items[j] = q.items[j], q.items[i]
+	q.index[q.items[i]] = i
+	q.index[q.items[j]] = j
+}
+
+// up moves the item at index i towards the root
+// until its parent has no greater key
+func (q *IndexedMinPQ) up(i int) {
+	for i > 0 {
+		p := (i - 1) / 2
+		if !q.less(i, p) {
+			break
+		}
+		q.swap(i, p)
+		i = p
+	}
+}
+
+// down moves the item at index i towards the leaves
+// until neither of its children has a smaller key
+func (q *IndexedMinPQ) down(i int) {
+	for {
+		smallest := i
+		l, r := 2*i+1, 2*i+2
+		if l < len(q.items) && q.less(l, smallest) {
+			smallest = l
+		}
+		if r < len(q.items) && q.less(r, smallest) {
+			smallest = r
+		}
+		if smallest == i {
+			return
+		}
+		q.swap(i, smallest)
+		i = smallest
+	}
+}
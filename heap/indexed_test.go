@@ -0,0 +1,79 @@
+package heap
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestIndexedMinPQ(t *testing.T) {
+	q := NewIndexedMinPQ()
+	if _, _, ok := q.ExtractMin(); ok {
+		t.Errorf("expected an empty queue")
+	}
+	keys := map[string]int{"a": 23, "b": 77, "c": 5, "d": 7, "e": 8, "f": 107, "g": 3, "h": 11}
+	for x, k := range keys {
+		if !q.Insert(x, k) {
+			t.Errorf("expected %s to be inserted", x)
+		}
+	}
+	if q.Insert("a", 0) {
+		t.Errorf("expected a to be in the queue already")
+	}
+	if q.DecreaseKey("c", 6) {
+		t.Errorf("expected the key of c not to be increased")
+	}
+	if q.DecreaseKey("z", 1) {
+		t.Errorf("expected z not to be in the queue")
+	}
+	for x, k := range map[string]int{"f": 2, "b": -23, "a": -77} {
+		keys[x] = k
+		if !q.DecreaseKey(x, k) {
+			t.Errorf("expected the key of %s to be decreased", x)
+		}
+	}
+	if k, ok := q.Key("b"); !ok || k != -23 {
+		t.Errorf("expected the key of b to be -23, got %d", k)
+	}
+	if x, k, _ := q.Min(); x != "a" || k != -77 {
+		t.Errorf("expected the minimum to be a with key -77, got %v with key %d", x, k)
+	}
+	expected := []string{"a", "b", "f", "g", "c", "d", "e", "h"}
+	for _, x := range expected {
+		if y, k, _ := q.ExtractMin(); y != x || k != keys[x] {
+			t.Errorf("expected %s with key %d, got %v with key %d", x, keys[x], y, k)
+		}
+		if q.Contains(x) {
+			t.Errorf("expected %s to be removed", x)
+		}
+	}
+	if !q.Empty() {
+		t.Errorf("expected an empty queue, got %d items", q.Len())
+	}
+}
+
+func TestIndexedMinPQRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	q := NewIndexedMinPQ()
+	keys := make(map[int]int)
+	for i := 0; i < 500; i++ {
+		keys[i] = r.Intn(1000)
+		q.Insert(i, keys[i])
+	}
+	for i := 0; i < 1000; i++ {
+		x := r.Intn(500)
+		keys[x] -= r.Intn(100) + 1
+		q.DecreaseKey(x, keys[x])
+	}
+	var sorted []int
+	for _, k := range keys {
+		sorted = append(sorted, k)
+	}
+	sort.Ints(sorted)
+	for _, k := range sorted {
+		x, key, _ := q.ExtractMin()
+		if key != k || keys[x.(int)] != k {
+			t.Fatalf("expected key %d, got %d", k, key)
+		}
+	}
+}